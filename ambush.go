@@ -0,0 +1,161 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import "fmt"
+
+// do_ambush_command executes an AMBUSH order:
+//
+//	AMBUSH amount
+//
+// The amount is spent preparing an ambush for enemy ships that jump into the
+// star system. It is used in the strikes that follow the jumps, and in any
+// other battle in the system for the rest of the turn.
+func (p *production_t) do_ambush_command(o *order_t) {
+	sp := p.species
+	amount, ok := o.get_value()
+	if !ok || amount <= 0 {
+		sp.report.order_ignored(o, "Invalid or missing amount to spend.")
+		return
+	}
+	if p.check_bounced(amount) {
+		sp.report.order_ignored(o, "Insufficient funds to execute order.")
+		return
+	}
+	p.nampla.use_on_ambush += amount
+	sp.report.printf("Spent %d in preparation for an ambush at PL %s.\n", amount, p.nampla.name)
+}
+
+// do_hide_command executes a HIDE order:
+//
+//	HIDE
+//
+// The colony is hidden from enemy scans and attacks during the next turn.
+// The cost is one tenth of the colony's economic base. The home planet can't be hidden.
+func (p *production_t) do_hide_command(o *order_t) {
+	sp := p.species
+	if p.nampla.status&HOME_PLANET != 0 {
+		sp.report.order_ignored(o, "You may not hide a home planet.")
+		return
+	} else if p.nampla.hiding {
+		sp.report.order_ignored(o, fmt.Sprintf("PL %s is already being hidden.", p.nampla.name))
+		return
+	}
+	cost := max((p.nampla.mi_base+p.nampla.ma_base)/100, 1)
+	if p.check_bounced(cost) {
+		sp.report.order_ignored(o, "Insufficient funds to execute order.")
+		return
+	}
+	p.nampla.hiding = true
+	sp.report.printf("Spent %d to hide PL %s.\n", cost, p.nampla.name)
+}
+
+// ambush_at returns the amount the species has spent on ambushes at its colonies in the star system.
+func (sp *species_data_t) ambush_at(x, y, z int) int {
+	amount := 0
+	for _, nampla := range sp.namplas {
+		if nampla.x == x && nampla.y == y && nampla.z == z {
+			amount += nampla.use_on_ambush
+		}
+	}
+	return amount
+}
+
+// just_jumped_tonnage returns the tonnage of the species' ships that jumped to the location this turn.
+// These ships can be surprised.
+func (sp *species_data_t) just_jumped_tonnage(x, y, z int) int {
+	tonnage := 0
+	for _, ship := range sp.ships {
+		if ship.x == x && ship.y == y && ship.z == z && ship.just_jumped && ship.status != UNDER_CONSTRUCTION {
+			tonnage += ship.tonnage
+		}
+	}
+	return tonnage
+}
+
+// check_surprise decides which species in the battle are taken by surprise.
+//
+// A species with ships that just jumped in can be surprised by an enemy that
+// spent EUs on an ambush. The odds grow with the largest amount spent by an
+// enemy and shrink with the tonnage of the ships that just jumped. After the
+// check, can_be_surprised is true only for species that were surprised, and
+// first_strike is true only for species whose ambush surprised an enemy.
+func (bat *battle_data_t) check_surprise() {
+	for i := 0; i < bat.num_species_here; i++ {
+		if !bat.can_be_surprised[i] {
+			continue
+		}
+		ambusher, ambush_amount := -1, 0
+		for k := 0; k < bat.num_species_here; k++ {
+			if k != i && bat.are_enemies(i, k) && bat.ambush_amount[k] > ambush_amount {
+				ambusher, ambush_amount = k, bat.ambush_amount[k]
+			}
+		}
+		if ambusher == -1 {
+			bat.can_be_surprised[i] = false
+			continue
+		}
+		tonnage := bat.species[i].just_jumped_tonnage(bat.x, bat.y, bat.z)
+		chance_of_surprise := (100 * ambush_amount) / (ambush_amount + 10*tonnage)
+		if rnd(100) > chance_of_surprise {
+			bat.can_be_surprised[i] = false
+			bat.log_printf(false, "  SP %s sprang an ambush on SP %s, but failed to take them by surprise.\n",
+				bat.species[ambusher].name, bat.species[i].name)
+			continue
+		}
+		bat.first_strike[ambusher] = true
+		bat.log_printf(false, "  SP %s ambushed SP %s! Ships that just jumped in can't fire in the first round.\n",
+			bat.species[ambusher].name, bat.species[i].name)
+	}
+}
+
+// is_concealed returns true if the species' only presence in the battle is hidden colonies.
+func (bat *battle_data_t) is_concealed(j int) bool {
+	sp := bat.species[j]
+	for _, ship := range sp.ships {
+		if ship.x == bat.x && ship.y == bat.y && ship.z == bat.z && ship.can_fight() {
+			return false
+		}
+	}
+	for _, nampla := range sp.namplas {
+		if nampla.x == bat.x && nampla.y == bat.y && nampla.z == bat.z && nampla.status&POPULATED != 0 && !nampla.hidden {
+			return false
+		}
+	}
+	return true
+}
+
+// is_disguised returns true if the species' only visible presence in the battle is disguised ships.
+func (bat *battle_data_t) is_disguised(j int) bool {
+	sp := bat.species[j]
+	for _, ship := range sp.ships {
+		if ship.x == bat.x && ship.y == bat.y && ship.z == bat.z && ship.can_fight() && !ship.is_disguised() {
+			return false
+		}
+	}
+	for _, nampla := range sp.namplas {
+		if nampla.x == bat.x && nampla.y == bat.y && nampla.z == bat.z && nampla.status&POPULATED != 0 && !nampla.hidden {
+			return false
+		}
+	}
+	return true
+}
+
+// report_hidden_colonies tells each species whose colony on the planet is hidden that it escaped an attack.
+func (bat *battle_data_t) report_hidden_colonies(pn int) {
+	for i := 0; i < bat.num_species_here; i++ {
+		if !bat.is_attacking(i, PLANET_ATTACK, pn) {
+			continue
+		}
+		for j := 0; j < bat.num_species_here; j++ {
+			if j == i || !bat.are_enemies(i, j) {
+				continue
+			}
+			for _, nampla := range bat.species[j].namplas {
+				if nampla.pn == pn && nampla.hidden && nampla.x == bat.x && nampla.y == bat.y && nampla.z == bat.z {
+					bat.species[j].report.printf("  PL %s was hidden and escaped the attack by SP %s.\n", nampla.name, bat.species[i].name)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"context"
+	"fmt"
+	"github.com/playbymail/fhgo/sqlc/sqlite3"
+	"io"
+	"strings"
+)
+
+// do_auto_command executes an AUTO order:
+//
+//	AUTO
+//
+// Orders for the next turn are generated for the species at the end of this
+// turn. The generated orders include an AUTO order, so a species that stops
+// sending orders keeps getting them until the player submits orders again.
+func (sp *species_data_t) do_auto_command(o *order_t) {
+	if o.remaining() {
+		sp.report.order_ignored(o, "Invalid auto command.")
+		return
+	}
+	sp.auto_orders = true
+	sp.report.printf("Orders will be generated for you for the next turn.\n")
+}
+
+// find_colonist_point returns the named planet for a ship's loading or
+// unloading point, or nil if the point isn't set or the colony is gone.
+func (sp *species_data_t) find_colonist_point(point nampla_id_t) *nampla_data_t {
+	if point == 0 {
+		return nil
+	} else if point == HOME_PLANET_POINT {
+		return sp.home.nampla
+	}
+	for _, nampla := range sp.namplas {
+		if nampla.id == point && nampla.status&DISBANDED_COLONY == 0 {
+			return nampla
+		}
+	}
+	return nil
+}
+
+// generate_auto_orders writes the next turn's orders for every species that
+// gave the AUTO command this turn.
+func (g *galaxy_data_t) generate_auto_orders() {
+	g.auto_orders = map[species_id_t]string{}
+	for _, sp := range g.species {
+		if !sp.auto_orders || sp.home.nampla == nil {
+			continue
+		}
+		g.auto_orders[sp.id] = sp.generate_orders()
+		sp.report.printf("\nOrders for turn %d were generated for you. Orders you submit will replace them.\n", g.turn_number+1)
+
+		// the generated orders give the AUTO command again
+		sp.auto_orders = false
+	}
+}
+
+// generate_orders returns a default set of orders for the species:
+//
+//   - transports that are empty fly back to their loading point, and those
+//     carrying colonists fly to their unloading point and unload there;
+//   - every colony that can spend its production keeps its planetary
+//     defenses at half of its economic base, loads colonists onto empty
+//     transports at the colony, and spends the rest on research.
+func (sp *species_data_t) generate_orders() string {
+	sb := &strings.Builder{}
+
+	var jumps, unloads []string
+	loads := map[*nampla_data_t][]*ship_data_t{}
+	for _, ship := range sp.ships {
+		if ship.class != TR || ship.status == UNDER_CONSTRUCTION {
+			continue
+		}
+		dest := sp.find_colonist_point(ship.loading_point)
+		if ship.item_quantity[CU] > 0 {
+			dest = sp.find_colonist_point(ship.unloading_point)
+		}
+		if dest == nil {
+			continue
+		}
+		if !ship.is_at(dest) {
+			if ship.type_ != FTL {
+				continue
+			}
+			jumps = append(jumps, fmt.Sprintf("Jump %s, PL %s", ship.ship_name(), dest.name))
+		}
+		if ship.item_quantity[CU] > 0 {
+			unloads = append(unloads, fmt.Sprintf("Unload %s", ship.ship_name()))
+		} else if ship.is_at(dest) {
+			loads[dest] = append(loads[dest], ship)
+		}
+	}
+
+	fmt.Fprintf(sb, "START PRE-DEPARTURE\nAuto\nEND\n\n")
+
+	fmt.Fprintf(sb, "START JUMPS\n")
+	for _, jump := range jumps {
+		fmt.Fprintf(sb, "%s\n", jump)
+	}
+	fmt.Fprintf(sb, "END\n\n")
+
+	fmt.Fprintf(sb, "START PRODUCTION\n")
+	for _, nampla := range sp.namplas {
+		if nampla.planet == nil || nampla.status&POPULATED == 0 || nampla.status&DISBANDED_COLONY != 0 {
+			continue
+		}
+		fmt.Fprintf(sb, "Production PL %s\n", nampla.name)
+		if nampla.status&(MINING_COLONY|RESORT_COLONY) != 0 {
+			continue
+		}
+		raw_material_units, production_capacity := sp.planet_output(nampla)
+		balance := min(raw_material_units+nampla.item_quantity[RM], production_capacity)
+
+		// keep the planetary defenses at half of the economic base
+		if needed := (nampla.mi_base+nampla.ma_base)/2 - nampla.item_quantity[PD]; needed > 0 && items[PD].cost > 0 {
+			if pds := min(needed, (balance/4)/items[PD].cost); pds > 0 {
+				fmt.Fprintf(sb, "Build %d PD\n", pds)
+				balance -= pds * items[PD].cost
+			}
+		}
+
+		// fill the transports waiting at the colony with colonists
+		pop_units := nampla.pop_units
+		for _, ship := range loads[nampla] {
+			if items[CU].carry < 1 || items[CU].cost < 1 {
+				break
+			}
+			cus := min(ship.cargo_available()/items[CU].carry, pop_units, balance/items[CU].cost)
+			if cus > 0 {
+				fmt.Fprintf(sb, "Build %d CU %s\n", cus, ship.ship_name())
+				balance -= cus * items[CU].cost
+				pop_units -= cus
+			}
+		}
+
+		// spend what is left on research, evenly across the fields
+		if share := balance / len(tech_abbr); share > 0 {
+			for _, abbr := range tech_abbr {
+				fmt.Fprintf(sb, "Research %d %s\n", share, abbr)
+			}
+		}
+	}
+	fmt.Fprintf(sb, "END\n\n")
+
+	fmt.Fprintf(sb, "START POST-ARRIVAL\n")
+	for _, unload := range unloads {
+		fmt.Fprintf(sb, "%s\n", unload)
+	}
+	fmt.Fprintf(sb, "END\n")
+
+	return sb.String()
+}
+
+// save_auto_orders stores the orders generated for the next turn exactly as
+// if the species had submitted them.
+func (g *galaxy_data_t) save_auto_orders(ctx context.Context, q *sqlite3.Queries) error {
+	for _, sp := range g.species {
+		orders, ok := g.auto_orders[sp.id]
+		if !ok {
+			continue
+		}
+		if err := store_orders(ctx, q, g.turn_number+1, sp.id, orders, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// store_orders checks that the orders can be parsed and stores them for the species and turn.
+func store_orders(ctx context.Context, q *sqlite3.Queries, turn_number int, species_id species_id_t, orders string, auto bool) error {
+	if _, err := parse_orders(strings.NewReader(orders)); err != nil {
+		return err
+	}
+	var auto_flag int64
+	if auto {
+		auto_flag = 1
+	}
+	return q.UpsertSpeciesOrders(ctx, sqlite3.UpsertSpeciesOrdersParams{
+		TurnNumber: int64(turn_number),
+		SpeciesID:  int64(species_id),
+		Auto:       auto_flag,
+		Orders:     orders,
+	})
+}
+
+// SubmitOrders stores the orders a species has submitted for a turn,
+// replacing any orders generated for it. If turn_number is zero, the orders
+// are for the current turn. The turn phases read their orders from the store.
+func SubmitOrders(ctx context.Context, q *sqlite3.Queries, turn_number, species_id int, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if turn_number == 0 {
+		row, err := q.GetGalaxy(ctx)
+		if err != nil {
+			return fmt.Errorf("galaxy: %w", err)
+		}
+		turn_number = int(row.TurnNumber)
+	}
+	return store_orders(ctx, q, turn_number, species_id_t(species_id), string(data), false)
+}
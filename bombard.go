@@ -0,0 +1,329 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+// do_planet_actions carries out the bombardment, germ warfare and siege
+// options aimed at a planet once the attack on it is over. Each needs the
+// attacker to have ships at the planet and no armed enemy ships or planetary
+// defenses left to defend it.
+func (g *galaxy_data_t) do_planet_actions(bat *battle_data_t, pn int) {
+	for i := 0; i < bat.num_species_here; i++ {
+		for n := 0; n < bat.num_engage_options[i]; n++ {
+			option := bat.engage_option[i][n]
+			if option < PLANET_BOMBARDMENT || bat.engage_planet[i][n] != pn {
+				continue
+			}
+			act := &action_data_t{}
+			if !bat.planet_action_params(option, pn, i, act) {
+				continue
+			}
+			switch option {
+			case PLANET_BOMBARDMENT:
+				bat.bombard(act)
+			case GERM_WARFARE:
+				bat.germ_warfare(i, act)
+			case SIEGE:
+				g.besiege(bat, i, act)
+			}
+		}
+	}
+}
+
+// planet_action_params sets up the attacking ships and the target colonies
+// for a bombardment, germ warfare or siege. Armed ships drop bombs equal to
+// their weapon damage; for germ warfare, every ship carrying GW bombs takes part.
+// Target colonies are typed NAMPLA, GENOCIDE_NAMPLA or BESIEGED_NAMPLA by option.
+// Returns false if the action isn't possible.
+func (bat *battle_data_t) planet_action_params(option combat_action_e, pn, i int, act *action_data_t) bool {
+	sp := bat.species[i]
+	for _, ship := range sp.ships {
+		if ship.x != bat.x || ship.y != bat.y || ship.z != bat.z || !ship.can_fight() || bat.is_captured(ship) {
+			continue
+		} else if ship.pn != pn && ship.status == ON_SURFACE {
+			continue
+		} else if option == GERM_WARFARE && ship.item_quantity[GW] == 0 {
+			continue
+		} else if option != GERM_WARFARE && ship.class == TR {
+			continue
+		} else if act.num_units_fighting >= MAX_SHIPS {
+			break
+		}
+		act.add_ship(i, sp, ship, false)
+		unit := act.num_units_fighting - 1
+		act.bomb_damage[unit] = act.weapon_damage[unit] * int64(act.num_shots[unit])
+	}
+	if act.num_units_fighting == 0 {
+		if option == GERM_WARFARE {
+			bat.log_printf(false, "\n  SP %s has no germ warfare bombs to use on planet #%d.\n", sp.name, pn)
+		} else {
+			bat.log_printf(false, "\n  SP %s has no armed ships to carry out %s of planet #%d.\n", sp.name, option, pn)
+		}
+		return false
+	}
+
+	// armed enemy ships and planetary defenses at the planet prevent the action
+	for j := 0; j < bat.num_species_here; j++ {
+		if j == i || !bat.are_enemies(i, j) {
+			continue
+		}
+		for _, ship := range bat.species[j].ships {
+			if ship.x == bat.x && ship.y == bat.y && ship.z == bat.z && ship.pn == pn && ship.can_fight() && !bat.is_captured(ship) && ship.class != TR {
+				bat.log_printf(false, "\n  Planet #%d is still defended. SP %s can't carry out %s.\n", pn, sp.name, option)
+				return false
+			}
+		}
+		for _, nampla := range bat.species[j].namplas {
+			if nampla.x == bat.x && nampla.y == bat.y && nampla.z == bat.z && nampla.pn == pn && nampla.item_quantity[PD] > 0 && !nampla.hidden {
+				bat.log_printf(false, "\n  Planet #%d is still defended. SP %s can't carry out %s.\n", pn, sp.name, option)
+				return false
+			}
+		}
+	}
+
+	unit_type := NAMPLA
+	switch option {
+	case GERM_WARFARE:
+		unit_type = GENOCIDE_NAMPLA
+	case SIEGE:
+		unit_type = BESIEGED_NAMPLA
+	}
+	targets := 0
+	for j := 0; j < bat.num_species_here; j++ {
+		if j == i || !bat.are_enemies(i, j) {
+			continue
+		}
+		for _, nampla := range bat.species[j].namplas {
+			if nampla.x != bat.x || nampla.y != bat.y || nampla.z != bat.z || nampla.pn != pn {
+				continue
+			} else if nampla.status&POPULATED == 0 || nampla.hidden || act.num_units_fighting >= MAX_SHIPS {
+				continue
+			}
+			unit := act.num_units_fighting
+			act.num_units_fighting++
+			act.fighting_species_index[unit] = j
+			act.unit_type[unit] = unit_type
+			act.fighting_unit[unit] = nampla
+			targets++
+		}
+	}
+	if targets == 0 {
+		bat.log_printf(true, "\n  There are no enemy colonies on planet #%d for SP %s to attack.\n", pn, sp.name)
+		return false
+	}
+	return true
+}
+
+// bombard destroys part of the economic base, population and inventory of
+// each target colony. The bomb damage is shared between the targets, and a
+// colony's economic base resists in proportion to its size. When a home planet
+// is bombed, its original base is saved so that it can recover.
+func (bat *battle_data_t) bombard(act *action_data_t) {
+	var total_bomb_damage int64
+	targets := 0
+	for unit := 0; unit < act.num_units_fighting; unit++ {
+		switch act.unit_type[unit] {
+		case SHIP:
+			total_bomb_damage += act.bomb_damage[unit]
+		case NAMPLA:
+			targets++
+		}
+	}
+	bomb_damage := total_bomb_damage / int64(targets)
+
+	for unit := 0; unit < act.num_units_fighting; unit++ {
+		if act.unit_type[unit] != NAMPLA {
+			continue
+		}
+		sp := bat.species[act.fighting_species_index[unit]]
+		nampla := act.fighting_unit[unit].(*nampla_data_t)
+		total_base := int64(nampla.mi_base + nampla.ma_base)
+		percent_damage := 100
+		if bomb_damage+2*total_base > 0 {
+			percent_damage = int((100 * bomb_damage) / (bomb_damage + 2*total_base))
+		}
+		if nampla.status&HOME_PLANET != 0 && sp.hp_original_base == 0 {
+			sp.hp_original_base = int(total_base)
+		}
+		reduce := func(n int) int { return n - (n*percent_damage)/100 }
+		nampla.mi_base, nampla.ma_base = reduce(nampla.mi_base), reduce(nampla.ma_base)
+		nampla.pop_units = reduce(nampla.pop_units)
+		nampla.shipyards = reduce(nampla.shipyards)
+		for _, item := range []item_e{CU, IU, AU, PD} {
+			nampla.item_quantity[item] = reduce(nampla.item_quantity[item])
+		}
+		bat.log_printf(false, "\n  PL %s of SP %s was bombarded: %d%% of its economic base and population was destroyed.\n",
+			nampla.name, sp.name, percent_damage)
+		sp.check_population(nampla)
+	}
+}
+
+// germ_warfare drops the attacker's GW bombs on each target colony in turn.
+// Each bomb wipes out the colony with a chance that depends on the BI tech
+// levels of the two species; bombs are used up whether or not they succeed.
+func (bat *battle_data_t) germ_warfare(i int, act *action_data_t) {
+	attacker := bat.species[i]
+	for target := 0; target < act.num_units_fighting; target++ {
+		if act.unit_type[target] != GENOCIDE_NAMPLA {
+			continue
+		}
+		sp := bat.species[act.fighting_species_index[target]]
+		nampla := act.fighting_unit[target].(*nampla_data_t)
+		chance_of_success := 0
+		if attacker.tech_level[BI] > 0 {
+			chance_of_success = (100 * attacker.tech_level[BI]) / (attacker.tech_level[BI] + sp.tech_level[BI])
+		}
+
+		bombs_used, wiped_out := 0, false
+		for unit := 0; unit < act.num_units_fighting && !wiped_out; unit++ {
+			if act.unit_type[unit] != SHIP {
+				continue
+			}
+			ship := act.fighting_unit[unit].(*ship_data_t)
+			for ship.item_quantity[GW] > 0 && !wiped_out {
+				ship.item_quantity[GW]--
+				bombs_used++
+				wiped_out = rnd(100) <= chance_of_success
+			}
+		}
+		if bombs_used == 0 {
+			break
+		}
+		if !wiped_out {
+			bat.log_printf(false, "\n  SP %s dropped %s on PL %s of SP %s, but the biological defenses held.\n",
+				attacker.name, item_quantity_name(GW, bombs_used), nampla.name, sp.name)
+			continue
+		}
+		if nampla.status&HOME_PLANET != 0 && sp.hp_original_base == 0 {
+			sp.hp_original_base = nampla.mi_base + nampla.ma_base
+		}
+		nampla.mi_base, nampla.ma_base, nampla.pop_units = 0, 0, 0
+		nampla.IUs_to_install, nampla.AUs_to_install = 0, 0
+		nampla.item_quantity = [MAX_ITEMS]int{}
+		bat.log_printf(false, "\n  SP %s dropped %s on PL %s of SP %s and wiped out the colony!\n",
+			attacker.name, item_quantity_name(GW, bombs_used), nampla.name, sp.name)
+		sp.check_population(nampla)
+	}
+}
+
+// besiege sets the siege effectiveness of each target colony and records the
+// siege as a transaction, so that part of the colony's production goes to the
+// besieger. Effectiveness grows with the power of the besieging ships and
+// shrinks with the colony's economic base, up to a maximum of 99 percent.
+func (g *galaxy_data_t) besiege(bat *battle_data_t, i int, act *action_data_t) {
+	besieger := bat.species[i]
+	var besieger_power int64
+	for unit := 0; unit < act.num_units_fighting; unit++ {
+		if act.unit_type[unit] == SHIP {
+			besieger_power += power(act.fighting_unit[unit].(*ship_data_t).tonnage)
+		}
+	}
+	for unit := 0; unit < act.num_units_fighting; unit++ {
+		if act.unit_type[unit] != BESIEGED_NAMPLA {
+			continue
+		}
+		sp := bat.species[act.fighting_species_index[unit]]
+		nampla := act.fighting_unit[unit].(*nampla_data_t)
+		econ_base := int64(nampla.mi_base+nampla.ma_base) / 10
+		siege_eff := int((100 * besieger_power) / (besieger_power + econ_base))
+		nampla.siege_eff = min(nampla.siege_eff+siege_eff, 99)
+		g.add_transaction(&trans_data_t{
+			type_:     BESIEGE_PLANET,
+			donor:     sp.id,
+			recipient: besieger.id,
+			value:     siege_eff,
+			x:         nampla.x,
+			y:         nampla.y,
+			z:         nampla.z,
+			pn:        nampla.pn,
+			name1:     nampla.name,
+			name2:     besieger.name,
+		})
+		bat.log_printf(false, "\n  SP %s has placed PL %s of SP %s under siege. Siege effectiveness is %d%%.\n",
+			besieger.name, nampla.name, sp.name, nampla.siege_eff)
+	}
+}
+
+// apply_siege takes the siege effectiveness share of a besieged colony's
+// production and sends it to the besiegers, in proportion to the
+// effectiveness of each one's siege. For mining and resort colonies, the
+// share is taken back from what the colony sent to the treasury.
+func (g *galaxy_data_t) apply_siege(p *production_t) {
+	nampla := p.nampla
+	if nampla.siege_eff == 0 || p.balance+p.treasury_transfer == 0 {
+		return
+	}
+	var sieges []*trans_data_t
+	total_eff := 0
+	for _, t := range g.transactions {
+		if t.type_ == BESIEGE_PLANET && t.donor == p.species.id && t.x == nampla.x && t.y == nampla.y && t.z == nampla.z && t.pn == nampla.pn {
+			sieges = append(sieges, t)
+			total_eff += t.value
+		}
+	}
+	lost_balance := (p.balance * nampla.siege_eff) / 100
+	p.balance -= lost_balance
+	p.eu_spending_limit = p.balance
+	lost_transfer := (p.treasury_transfer * nampla.siege_eff) / 100
+	p.treasury_transfer -= lost_transfer
+	p.species.econ_units -= lost_transfer
+	lost := lost_balance + lost_transfer
+	p.species.report.printf("PL %s is under siege: %d economic units of its production were lost to the besiegers.\n", nampla.name, lost)
+	for _, t := range sieges {
+		besieger := g.find_species(t.recipient)
+		amount := (lost * t.value) / total_eff
+		if besieger == nil || amount == 0 {
+			continue
+		}
+		besieger.econ_units += amount
+		besieger.report.printf("The siege of PL %s of SP %s yielded %d economic units.\n", nampla.name, p.species.name, amount)
+		g.add_transaction(&trans_data_t{
+			type_:     SIEGE_EU_TRANSFER,
+			donor:     p.species.id,
+			recipient: besieger.id,
+			value:     amount,
+			x:         nampla.x,
+			y:         nampla.y,
+			z:         nampla.z,
+			pn:        nampla.pn,
+			name1:     nampla.name,
+		})
+	}
+}
+
+// find_species returns the species with the given id, or nil if there isn't one.
+func (g *galaxy_data_t) find_species(id species_id_t) *species_data_t {
+	for _, sp := range g.species {
+		if sp.id == id {
+			return sp
+		}
+	}
+	return nil
+}
+
+// recover_home_planet rebuilds a bombed home planet by five percent of its
+// original economic base each turn, until the original base is reached.
+func (sp *species_data_t) recover_home_planet() {
+	home := sp.home.nampla
+	if sp.hp_original_base == 0 || home == nil {
+		return
+	}
+	total_base := home.mi_base + home.ma_base
+	if shortfall := sp.hp_original_base - total_base; shortfall > 0 {
+		recovery := min(max(sp.hp_original_base/20, 1), shortfall)
+		mi_share := recovery / 2
+		if total_base > 0 {
+			mi_share = (recovery * home.mi_base) / total_base
+		}
+		home.mi_base += mi_share
+		home.ma_base += recovery - mi_share
+		total_base += recovery
+		sp.check_population(home)
+	}
+	if total_base >= sp.hp_original_base {
+		sp.hp_original_base = 0
+		sp.report.printf("\nPL %s has fully recovered from the attacks on it.\n", home.name)
+	} else {
+		sp.report.printf("\nPL %s is recovering: its economic base is %d%% of its original value.\n",
+			home.name, (100*total_base)/sp.hp_original_base)
+	}
+}
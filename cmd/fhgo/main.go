@@ -4,14 +4,18 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/mdhender/semver"
 	"github.com/playbymail/fhgo"
+	"github.com/playbymail/fhgo/combat"
 	"github.com/playbymail/fhgo/prng"
 	"github.com/playbymail/fhgo/sqlc/sqlite3"
 	"github.com/spf13/cobra"
 	"log"
+	"os"
 	"path/filepath"
+	"strconv"
 )
 
 func main() {
@@ -38,7 +42,6 @@ func main() {
 		cmdProduction,
 		cmdReport,
 		cmdScan,
-		cmdScanNear,
 		cmdSexpr,
 		cmdShow,
 		cmdStats,
@@ -47,6 +50,8 @@ func main() {
 		cmdVersion,
 	)
 
+	cmdCombat.Flags().BoolVar(&argsCombat.strikes, "strikes", false, "run the strikes that follow the jumps")
+
 	cmdCreate.AddCommand(cmdCreateGalaxy, cmdCreateHomeSystemTemplates, cmdCreateSpecies)
 	cmdCreateGalaxy.Flags().BoolVar(&argsCreateGalaxy.deriveSizes, "derive-sizes", false, "derive radius and number of stars from number of species")
 	cmdCreateGalaxy.Flags().BoolVar(&argsCreateGalaxy.lessCrowded, "less-crowded", false, "increases number of stars by 50% for slower-paced games")
@@ -61,9 +66,19 @@ func main() {
 	cmdDbInit.Flags().StringVar(&argsRoot.db.code, "code", "FH", "code to assign to the game")
 	cmdDbInit.Flags().StringVar(&argsRoot.db.name, "name", "gamma", "name to assign to the game")
 	cmdDbInit.Flags().StringVar(&argsRoot.db.description, "description", "", "description of the game")
+	cmdDbInit.Flags().StringVar(&argsRoot.db.items, "items", "", "path to a JSON file of item catalog overrides")
 	cmdRoot.AddCommand(cmdVersion)
 
+	cmdImport.AddCommand(cmdImportOrders)
+	cmdImportOrders.Flags().IntVar(&argsImportOrders.species, "species", 0, "species that submitted the orders")
+	cmdImportOrders.Flags().IntVar(&argsImportOrders.turn, "turn", 0, "turn the orders are for, defaults to the current turn")
+
 	cmdScan.AddCommand(cmdScanNear)
+	cmdScanNear.Flags().IntVar(&argsScan.radius, "radius", 10, "radius of the search in parsecs")
+
+	cmdShow.AddCommand(cmdShowPlanet, cmdShowPopulation, cmdShowRelations)
+	cmdShowPopulation.Flags().IntVar(&argsShowPopulation.species, "species", 0, "species to show, defaults to all species")
+	cmdShowRelations.Flags().IntVar(&argsShowRelations.turn, "turn", 0, "turn to show, defaults to the last turn recorded")
 
 	if err := cmdRoot.Execute(); err != nil {
 		log.Fatal(err)
@@ -79,6 +94,7 @@ var (
 			code        string // code for the game
 			description string // description of the game
 			forceCreate bool   // if true, overwrite existing database
+			items       string // path to a JSON file of item catalog overrides
 			name        string // name of the game
 		}
 	}
@@ -92,11 +108,30 @@ var (
 		},
 	}
 
+	argsCombat struct {
+		strikes bool // run the strikes after the jumps instead of the battles at the start of the turn
+	}
+
 	cmdCombat = &cobra.Command{
 		Use:   "combat",
-		Short: "combat stub",
+		Short: "Run the combat phase of the current turn",
+		Long:  `Run the combat phase of the current turn. With --strikes, run the strikes that follow the jumps.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return requireDatabase()
+		},
 		Run: func(cmd *cobra.Command, args []string) {
-			cmd.Printf("Command 'combat' not yet implemented\n")
+			q, closer, err := sqlite3.DatabaseOpen(argsRoot.db.path, context.Background())
+			if err != nil {
+				log.Fatalf("combat: %v\n", err)
+			}
+			defer closer()
+			run := combat.Run
+			if argsCombat.strikes {
+				run = combat.RunStrikes
+			}
+			if err := run(context.Background(), q); err != nil {
+				log.Fatalf("combat: %v\n", err)
+			}
 		},
 	}
 
@@ -234,6 +269,19 @@ var (
 
 			// initialize a new game in the database
 			log.Printf("db: init: creating new game\n")
+			q, closer, err := sqlite3.DatabaseOpen(argsRoot.db.path, context.Background())
+			if err != nil {
+				log.Fatalf("db: init: %v\n", err)
+			}
+			defer closer()
+			if err := fhgo.LoadShipClasses(context.Background(), q); err != nil {
+				log.Fatalf("db: init: %v\n", err)
+			}
+			log.Printf("db: init: loaded ship class catalog\n")
+			if err := fhgo.LoadItems(context.Background(), q, argsRoot.db.items); err != nil {
+				log.Fatalf("db: init: %v\n", err)
+			}
+			log.Printf("db: init: loaded item catalog\n")
 			log.Printf("db: init: todo: implement the game initialization\n")
 			log.Printf("db: created new game\n")
 		},
@@ -249,9 +297,19 @@ var (
 
 	cmdFinish = &cobra.Command{
 		Use:   "finish",
-		Short: "finish stub",
+		Short: "Finish the current turn and start the next one",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return requireDatabase()
+		},
 		Run: func(cmd *cobra.Command, args []string) {
-			cmd.Printf("Command 'finish' not yet implemented\n")
+			q, closer, err := sqlite3.DatabaseOpen(argsRoot.db.path, context.Background())
+			if err != nil {
+				log.Fatalf("finish: %v\n", err)
+			}
+			defer closer()
+			if err := fhgo.FinishTurn(context.Background(), q); err != nil {
+				log.Fatalf("finish: %v\n", err)
+			}
 		},
 	}
 
@@ -263,6 +321,44 @@ var (
 		},
 	}
 
+	argsImportOrders struct {
+		species int // species that submitted the orders
+		turn    int // turn the orders are for, zero for the current turn
+	}
+
+	cmdImportOrders = &cobra.Command{
+		Use:   "orders file",
+		Short: "Submit a species' orders for a turn",
+		Long:  `Submit a species' orders for a turn. The orders replace any submitted or generated earlier, and are executed by the turn phases.`,
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireDatabase(); err != nil {
+				return err
+			}
+			if argsImportOrders.species < 1 {
+				return fmt.Errorf("species: is required\n")
+			} else if argsImportOrders.turn < 0 {
+				return fmt.Errorf("turn: must not be negative\n")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			fd, err := os.Open(args[0])
+			if err != nil {
+				log.Fatalf("import: orders: %v\n", err)
+			}
+			defer fd.Close()
+			q, closer, err := sqlite3.DatabaseOpen(argsRoot.db.path, context.Background())
+			if err != nil {
+				log.Fatalf("import: orders: %v\n", err)
+			}
+			defer closer()
+			if err := fhgo.SubmitOrders(context.Background(), q, argsImportOrders.turn, argsImportOrders.species, fd); err != nil {
+				log.Fatalf("import: orders: %v\n", err)
+			}
+		},
+	}
+
 	cmdInspect = &cobra.Command{
 		Use:   "inspect",
 		Short: "inspect stub",
@@ -273,9 +369,19 @@ var (
 
 	cmdJump = &cobra.Command{
 		Use:   "jump",
-		Short: "jump stub",
+		Short: "Run the jump phase of the current turn",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return requireDatabase()
+		},
 		Run: func(cmd *cobra.Command, args []string) {
-			cmd.Printf("Command 'jump' not yet implemented\n")
+			q, closer, err := sqlite3.DatabaseOpen(argsRoot.db.path, context.Background())
+			if err != nil {
+				log.Fatalf("jump: %v\n", err)
+			}
+			defer closer()
+			if err := fhgo.RunJumps(context.Background(), q); err != nil {
+				log.Fatalf("jump: %v\n", err)
+			}
 		},
 	}
 
@@ -319,25 +425,55 @@ var (
 
 	cmdPostArrival = &cobra.Command{
 		Use:   "post-arrival",
-		Short: "post-arrival stub",
+		Short: "Run the post-arrival phase of the current turn",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return requireDatabase()
+		},
 		Run: func(cmd *cobra.Command, args []string) {
-			cmd.Printf("Command 'post-arrival' not yet implemented\n")
+			q, closer, err := sqlite3.DatabaseOpen(argsRoot.db.path, context.Background())
+			if err != nil {
+				log.Fatalf("post-arrival: %v\n", err)
+			}
+			defer closer()
+			if err := fhgo.RunPostArrival(context.Background(), q); err != nil {
+				log.Fatalf("post-arrival: %v\n", err)
+			}
 		},
 	}
 
 	cmdPreDeparture = &cobra.Command{
 		Use:   "pre-departure",
-		Short: "pre-departure stub",
+		Short: "Run the pre-departure phase of the current turn",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return requireDatabase()
+		},
 		Run: func(cmd *cobra.Command, args []string) {
-			cmd.Printf("Command 'pre-departure' not yet implemented\n")
+			q, closer, err := sqlite3.DatabaseOpen(argsRoot.db.path, context.Background())
+			if err != nil {
+				log.Fatalf("pre-departure: %v\n", err)
+			}
+			defer closer()
+			if err := fhgo.RunPreDeparture(context.Background(), q); err != nil {
+				log.Fatalf("pre-departure: %v\n", err)
+			}
 		},
 	}
 
 	cmdProduction = &cobra.Command{
 		Use:   "production",
-		Short: "production stub",
+		Short: "Run the production phase of the current turn",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return requireDatabase()
+		},
 		Run: func(cmd *cobra.Command, args []string) {
-			cmd.Printf("Command 'production' not yet implemented\n")
+			q, closer, err := sqlite3.DatabaseOpen(argsRoot.db.path, context.Background())
+			if err != nil {
+				log.Fatalf("production: %v\n", err)
+			}
+			defer closer()
+			if err := fhgo.RunProduction(context.Background(), q); err != nil {
+				log.Fatalf("production: %v\n", err)
+			}
 		},
 	}
 
@@ -350,18 +486,54 @@ var (
 	}
 
 	cmdScan = &cobra.Command{
-		Use:   "scan",
-		Short: "scan stub",
+		Use:   "scan x y z",
+		Short: "Scan the star system at the given coordinates",
+		Args:  cobra.ExactArgs(3),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireDatabase(); err != nil {
+				return err
+			}
+			return parseCoordinates(args, &argsScan.x, &argsScan.y, &argsScan.z)
+		},
 		Run: func(cmd *cobra.Command, args []string) {
-			cmd.Printf("Command 'scan' not yet implemented\n")
+			q, closer, err := sqlite3.DatabaseOpen(argsRoot.db.path, context.Background())
+			if err != nil {
+				log.Fatalf("scan: %v\n", err)
+			}
+			defer closer()
+			if err := fhgo.ScanSystem(context.Background(), q, cmd.OutOrStdout(), argsScan.x, argsScan.y, argsScan.z); err != nil {
+				log.Fatalf("scan: %v\n", err)
+			}
 		},
 	}
 
+	argsScan struct {
+		x, y, z int // coordinates to scan from
+		radius  int // radius of the search in parsecs
+	}
+
 	cmdScanNear = &cobra.Command{
-		Use:   "near",
-		Short: "scan near stub",
+		Use:   "near x y z",
+		Short: "List the star systems near the given coordinates, nearest first",
+		Args:  cobra.ExactArgs(3),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireDatabase(); err != nil {
+				return err
+			}
+			if argsScan.radius < 1 {
+				return fmt.Errorf("radius: must be at least 1\n")
+			}
+			return parseCoordinates(args, &argsScan.x, &argsScan.y, &argsScan.z)
+		},
 		Run: func(cmd *cobra.Command, args []string) {
-			cmd.Printf("Command 'scan near' not yet implemented\n")
+			q, closer, err := sqlite3.DatabaseOpen(argsRoot.db.path, context.Background())
+			if err != nil {
+				log.Fatalf("scan: near: %v\n", err)
+			}
+			defer closer()
+			if err := fhgo.ScanNear(context.Background(), q, cmd.OutOrStdout(), argsScan.x, argsScan.y, argsScan.z, argsScan.radius); err != nil {
+				log.Fatalf("scan: near: %v\n", err)
+			}
 		},
 	}
 
@@ -381,6 +553,97 @@ var (
 		},
 	}
 
+	argsShowRelations struct {
+		turn int // turn to show, zero for the last turn recorded
+	}
+
+	cmdShowPlanet = &cobra.Command{
+		Use:   "planet x y z pn",
+		Short: "Show the history of changes made to a planet",
+		Args:  cobra.ExactArgs(4),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireDatabase(); err != nil {
+				return err
+			}
+			if err := parseCoordinates(args, &argsShowPlanet.x, &argsShowPlanet.y, &argsShowPlanet.z); err != nil {
+				return err
+			}
+			pn, err := strconv.Atoi(args[3])
+			if err != nil || pn < 1 || pn > 9 {
+				return fmt.Errorf("pn: %q is not a planet number\n", args[3])
+			}
+			argsShowPlanet.pn = pn
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			q, closer, err := sqlite3.DatabaseOpen(argsRoot.db.path, context.Background())
+			if err != nil {
+				log.Fatalf("show: planet: %v\n", err)
+			}
+			defer closer()
+			if err := fhgo.ShowPlanetHistory(context.Background(), q, cmd.OutOrStdout(), argsShowPlanet.x, argsShowPlanet.y, argsShowPlanet.z, argsShowPlanet.pn); err != nil {
+				log.Fatalf("show: planet: %v\n", err)
+			}
+		},
+	}
+
+	argsShowPlanet struct {
+		x, y, z int // coordinates of the star system
+		pn      int // orbit of the planet
+	}
+
+	cmdShowPopulation = &cobra.Command{
+		Use:   "population",
+		Short: "Show the population history of every colony",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireDatabase(); err != nil {
+				return err
+			}
+			if argsShowPopulation.species < 0 {
+				return fmt.Errorf("species: must not be negative\n")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			q, closer, err := sqlite3.DatabaseOpen(argsRoot.db.path, context.Background())
+			if err != nil {
+				log.Fatalf("show: population: %v\n", err)
+			}
+			defer closer()
+			if err := fhgo.ShowPopulation(context.Background(), q, cmd.OutOrStdout(), argsShowPopulation.species); err != nil {
+				log.Fatalf("show: population: %v\n", err)
+			}
+		},
+	}
+
+	argsShowPopulation struct {
+		species int // species to show, zero for all species
+	}
+
+	cmdShowRelations = &cobra.Command{
+		Use:   "relations",
+		Short: "Show the matrix of relations between species",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireDatabase(); err != nil {
+				return err
+			}
+			if argsShowRelations.turn < 0 {
+				return fmt.Errorf("turn: must not be negative\n")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			q, closer, err := sqlite3.DatabaseOpen(argsRoot.db.path, context.Background())
+			if err != nil {
+				log.Fatalf("show: relations: %v\n", err)
+			}
+			defer closer()
+			if err := fhgo.ShowRelations(context.Background(), q, cmd.OutOrStdout(), argsShowRelations.turn); err != nil {
+				log.Fatalf("show: relations: %v\n", err)
+			}
+		},
+	}
+
 	cmdStats = &cobra.Command{
 		Use:   "stats",
 		Short: "stats stub",
@@ -414,3 +677,27 @@ var (
 		},
 	}
 )
+
+// requireDatabase checks that the database path was given and makes it absolute.
+func requireDatabase() error {
+	if argsRoot.db.path == "" {
+		return fmt.Errorf("database: path is required\n")
+	} else if path, err := filepath.Abs(argsRoot.db.path); err != nil {
+		return fmt.Errorf("database: %v\n", err)
+	} else {
+		argsRoot.db.path = path
+	}
+	return nil
+}
+
+// parseCoordinates parses x, y and z coordinates from the command arguments.
+func parseCoordinates(args []string, x, y, z *int) error {
+	for n, p := range []*int{x, y, z} {
+		value, err := strconv.Atoi(args[n])
+		if err != nil {
+			return fmt.Errorf("coordinates: %q is not a number\n", args[n])
+		}
+		*p = value
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// find_star returns the star system at the given coordinates, or nil if there isn't one.
+func (g *galaxy_data_t) find_star(x, y, z int) *star_data_t {
+	for _, star := range g.stars {
+		if star.x == x && star.y == y && star.z == z {
+			return star
+		}
+	}
+	return nil
+}
+
+// has_been_in returns true if the species has visited the star system or has a ship there now.
+func (sp *species_data_t) has_been_in(star *star_data_t) bool {
+	if star.visited_by[sp.id] {
+		return true
+	}
+	for _, ship := range sp.ships {
+		if ship.x == star.x && ship.y == star.y && ship.z == star.z && ship.status != UNDER_CONSTRUCTION {
+			return true
+		}
+	}
+	return false
+}
+
+// do_name_command executes a NAME order:
+//
+//	NAME x y z planet_number PL name
+//
+// The species must have visited the star system. The new named planet is
+// a colony, but it isn't populated until colonists are unloaded on it.
+func (g *galaxy_data_t) do_name_command(sp *species_data_t, o *order_t) {
+	var coords [4]int
+	for i := range coords {
+		value, ok := o.get_value()
+		if !ok {
+			sp.report.order_ignored(o, "Invalid or missing coordinates.")
+			return
+		}
+		coords[i] = value
+	}
+	x, y, z, pn := coords[0], coords[1], coords[2], coords[3]
+	star := g.find_star(x, y, z)
+	if star == nil {
+		sp.report.order_ignored(o, "There is no star system at those coordinates.")
+		return
+	} else if pn < 1 || pn > star.num_planets || star.planets[pn] == nil {
+		sp.report.order_ignored(o, "Invalid planet number.")
+		return
+	} else if !sp.has_been_in(star) {
+		sp.report.order_ignored(o, "You have not visited that star system.")
+		return
+	}
+	if o.get_class_abbr() != PLANET_ID {
+		sp.report.order_ignored(o, "Invalid or missing planet abbreviation.")
+		return
+	}
+	name := o.get_name()
+	if name == "" {
+		sp.report.order_ignored(o, "Invalid or missing planet name.")
+		return
+	}
+	for _, nampla := range sp.namplas {
+		if strings.EqualFold(nampla.name, name) {
+			sp.report.order_ignored(o, fmt.Sprintf("You already have a planet named %q.", nampla.name))
+			return
+		} else if nampla.x == x && nampla.y == y && nampla.z == z && nampla.pn == pn && nampla.status&DISBANDED_COLONY == 0 {
+			sp.report.order_ignored(o, fmt.Sprintf("You have already named that planet PL %s.", nampla.name))
+			return
+		}
+	}
+
+	nampla := &nampla_data_t{
+		id:     nampla_id_t(len(sp.namplas) + 1),
+		name:   name,
+		x:      x,
+		y:      y,
+		z:      z,
+		pn:     pn,
+		status: COLONY,
+		star:   star,
+		planet: star.planets[pn],
+	}
+	sp.namplas = append(sp.namplas, nampla)
+	sp.num_namplas++
+	sp.report.printf("Named planet #%d at x = %d, y = %d, z = %d PL %s.\n", pn, x, y, z, name)
+}
+
+// do_unload_command executes an UNLOAD order:
+//
+//	UNLOAD ship
+//
+// All colonists, mining units and manufacturing units on the ship are moved
+// to the species' named planet at the ship's location. The units are installed
+// automatically at the end of the post-arrival phase.
+func (sp *species_data_t) do_unload_command(o *order_t) {
+	if o.get_class_abbr() != SHIP_CLASS {
+		sp.report.order_ignored(o, "Invalid or missing ship.")
+		return
+	}
+	name := o.get_name()
+	ship := sp.find_ship(name)
+	if ship == nil {
+		sp.report.order_ignored(o, fmt.Sprintf("You do not have a ship named %q.", name))
+		return
+	} else if ship.status == UNDER_CONSTRUCTION {
+		sp.report.order_ignored(o, fmt.Sprintf("%s is still under construction.", ship.ship_name()))
+		return
+	}
+	var nampla *nampla_data_t
+	for _, n := range sp.namplas {
+		if ship.is_at(n) && n.status&DISBANDED_COLONY == 0 {
+			nampla = n
+			break
+		}
+	}
+	if nampla == nil {
+		sp.report.order_ignored(o, fmt.Sprintf("%s is not at one of your named planets.", ship.ship_name()))
+		return
+	}
+
+	cus, ius, aus := ship.item_quantity[CU], ship.item_quantity[IU], ship.item_quantity[AU]
+	if cus+ius+aus == 0 {
+		sp.report.order_ignored(o, fmt.Sprintf("%s has nothing to unload.", ship.ship_name()))
+		return
+	}
+	ship.item_quantity[CU], ship.item_quantity[IU], ship.item_quantity[AU] = 0, 0, 0
+	nampla.item_quantity[CU] += cus
+	if cus > 0 {
+		ship.unloading_point = colonist_point(nampla)
+	}
+	nampla.IUs_to_install += ius
+	nampla.AUs_to_install += aus
+
+	// colonists arriving without units of their own will use the units that
+	// were waiting for them on the planet
+	if cus > 0 && ius == 0 && aus == 0 {
+		nampla.auto_IUs += nampla.IUs_needed
+		nampla.auto_AUs += nampla.AUs_needed
+		nampla.IUs_needed, nampla.AUs_needed = 0, 0
+	}
+
+	sp.report.printf("%s unloaded %s, %s and %s at PL %s.\n", ship.ship_name(),
+		item_quantity_name(CU, cus), item_quantity_name(IU, ius), item_quantity_name(AU, aus), nampla.name)
+}
+
+// do_install_command executes an INSTALL order:
+//
+//	INSTALL [quantity] IU|AU PL name
+//	INSTALL [quantity] SU BA name
+//
+// Each IU or AU installed needs one colonist unit. If the quantity is missing or
+// zero, all units available on the planet are installed. Units that can't be
+// installed for want of colonists are installed automatically when colonists
+// are unloaded on the planet. SUs are installed in starbases; see install_starbase_units.
+func (sp *species_data_t) do_install_command(o *order_t) {
+	quantity, ok := o.get_value()
+	if !ok {
+		quantity = 0
+	} else if quantity < 0 {
+		sp.report.order_ignored(o, "Invalid quantity.")
+		return
+	}
+	if o.get_class_abbr() != ITEM_CLASS || (item_e(o.abbr_index) != IU && item_e(o.abbr_index) != AU && item_e(o.abbr_index) != SU) {
+		sp.report.order_ignored(o, "Only IUs, AUs and SUs may be installed.")
+		return
+	}
+	item := item_e(o.abbr_index)
+	if item == SU {
+		sp.install_starbase_units(o, quantity)
+		return
+	}
+	if o.get_class_abbr() != PLANET_ID {
+		sp.report.order_ignored(o, "Invalid or missing planet abbreviation.")
+		return
+	}
+	name := o.get_name()
+	nampla := sp.find_nampla(name)
+	if nampla == nil {
+		sp.report.order_ignored(o, fmt.Sprintf("You do not have a planet named %q.", name))
+		return
+	} else if nampla.status&DISBANDED_COLONY != 0 {
+		sp.report.order_ignored(o, "Units can't be installed on a disbanded colony.")
+		return
+	}
+
+	available := nampla.item_quantity[item]
+	if quantity == 0 {
+		quantity = available
+	} else if quantity > available {
+		sp.report.order_ignored(o, fmt.Sprintf("PL %s has only %s.", nampla.name, item_quantity_name(item, available)))
+		return
+	}
+	if quantity == 0 {
+		sp.report.order_ignored(o, fmt.Sprintf("PL %s has no %ss to install.", nampla.name, items[item].abbr))
+		return
+	}
+
+	installed := nampla.install(item, quantity)
+	if installed > 0 {
+		sp.report.printf("Installed %s on PL %s.\n", item_quantity_name(item, installed), nampla.name)
+	}
+	if waiting := quantity - installed; waiting > 0 {
+		if item == IU {
+			nampla.IUs_needed += waiting
+		} else {
+			nampla.AUs_needed += waiting
+		}
+		sp.report.printf("Not enough colonists on PL %s: %s will be installed when colonists arrive.\n",
+			nampla.name, item_quantity_name(item, waiting))
+	}
+}
+
+// install moves up to quantity units from the planet's inventory into its
+// mining or manufacturing base, using one colonist unit for each.
+// Returns the number of units installed.
+func (nampla *nampla_data_t) install(item item_e, quantity int) int {
+	if quantity > nampla.item_quantity[item] {
+		quantity = nampla.item_quantity[item]
+	}
+	if quantity > nampla.item_quantity[CU] {
+		quantity = nampla.item_quantity[CU]
+	}
+	if quantity <= 0 {
+		return 0
+	}
+	nampla.item_quantity[item] -= quantity
+	nampla.item_quantity[CU] -= quantity
+	if item == IU {
+		nampla.mi_base += quantity
+	} else {
+		nampla.ma_base += quantity
+	}
+	return quantity
+}
+
+// auto_install installs the units unloaded or waiting on each of the
+// species' colonies, then updates the status of the colonies.
+func (sp *species_data_t) auto_install() {
+	for _, nampla := range sp.namplas {
+		if nampla.status&DISBANDED_COLONY != 0 {
+			continue
+		}
+
+		// unloaded units are installed first, then units that were waiting for colonists
+		nampla.item_quantity[IU] += nampla.IUs_to_install
+		nampla.item_quantity[AU] += nampla.AUs_to_install
+		ius := nampla.install(IU, nampla.IUs_to_install+nampla.auto_IUs)
+		aus := nampla.install(AU, nampla.AUs_to_install+nampla.auto_AUs)
+		nampla.IUs_needed += nampla.IUs_to_install + nampla.auto_IUs - ius
+		nampla.AUs_needed += nampla.AUs_to_install + nampla.auto_AUs - aus
+		nampla.IUs_to_install, nampla.AUs_to_install = 0, 0
+		nampla.auto_IUs, nampla.auto_AUs = 0, 0
+		if ius+aus > 0 {
+			sp.report.printf("Automatically installed %s and %s on PL %s.\n",
+				item_quantity_name(IU, ius), item_quantity_name(AU, aus), nampla.name)
+		}
+
+		sp.check_population(nampla)
+	}
+}
+
+// check_population sets the POPULATED, MINING_COLONY and RESORT_COLONY bits
+// of a named planet to match its population and installed units.
+// Returns true if the planet is populated.
+func (sp *species_data_t) check_population(nampla *nampla_data_t) bool {
+	was_populated := nampla.status&POPULATED != 0
+
+	total_pop := nampla.mi_base + nampla.ma_base + nampla.IUs_to_install + nampla.AUs_to_install +
+		nampla.item_quantity[PD] + nampla.item_quantity[CU] + nampla.pop_units
+	if total_pop == 0 {
+		nampla.status &^= POPULATED | MINING_COLONY | RESORT_COLONY
+		if was_populated {
+			sp.report.printf("PL %s has been depopulated.\n", nampla.name)
+		}
+		return false
+	}
+	nampla.status |= POPULATED
+
+	// the home planet is never a mining or resort colony
+	nampla.status &^= MINING_COLONY | RESORT_COLONY
+	if nampla.status&HOME_PLANET == 0 {
+		switch {
+		case nampla.mi_base > 0 && nampla.ma_base == 0:
+			nampla.status |= MINING_COLONY
+		case nampla.ma_base > 0 && nampla.mi_base == 0 && sp.is_resort_planet(nampla.planet):
+			nampla.status |= RESORT_COLONY
+		}
+	}
+	return true
+}
+
+// is_resort_planet returns true if a colony on the planet with only
+// manufacturing units installed would be a resort colony. Resorts need an
+// easily habitable planet with gravity no higher than the home planet's.
+func (sp *species_data_t) is_resort_planet(planet *planet_data_t) bool {
+	if planet == nil || sp.home.planet == nil {
+		return false
+	}
+	return life_support_needed(sp, sp.home.planet, planet) <= 6 && planet.gravity <= sp.home.planet.gravity
+}
+
+// do_disband_command executes a DISBAND order:
+//
+//	DISBAND PL name
+//
+// The colony's population, installed units and inventory are lost. The
+// home planet can't be disbanded. The colony is recorded in the ledger as disbanded.
+func (g *galaxy_data_t) do_disband_command(sp *species_data_t, o *order_t) {
+	if o.get_class_abbr() != PLANET_ID {
+		sp.report.order_ignored(o, "Invalid or missing planet abbreviation.")
+		return
+	}
+	name := o.get_name()
+	nampla := sp.find_nampla(name)
+	if nampla == nil {
+		sp.report.order_ignored(o, fmt.Sprintf("You do not have a planet named %q.", name))
+		return
+	} else if nampla.status&HOME_PLANET != 0 {
+		sp.report.order_ignored(o, "You can't disband your home planet.")
+		return
+	} else if nampla.status&DISBANDED_COLONY != 0 {
+		sp.report.order_ignored(o, fmt.Sprintf("PL %s has already been disbanded.", nampla.name))
+		return
+	} else if g.ledger_full() {
+		sp.report.order_ignored(o, "Too many transactions this turn.")
+		return
+	}
+	nampla.disband()
+	g.add_transaction(&trans_data_t{
+		type_:     COLONY_DISBANDED,
+		donor:     sp.id,
+		recipient: sp.id,
+		x:         nampla.x,
+		y:         nampla.y,
+		z:         nampla.z,
+		pn:        nampla.pn,
+		name1:     "PL " + nampla.name,
+	})
+	sp.report.printf("PL %s has been disbanded.\n", nampla.name)
+}
+
+// disband clears the population and holdings of a colony and marks it as disbanded.
+func (nampla *nampla_data_t) disband() {
+	nampla.status = DISBANDED_COLONY
+	nampla.hiding, nampla.hidden = false, false
+	nampla.siege_eff = 0
+	nampla.shipyards = 0
+	nampla.IUs_needed, nampla.AUs_needed = 0, 0
+	nampla.auto_IUs, nampla.auto_AUs = 0, 0
+	nampla.IUs_to_install, nampla.AUs_to_install = 0, 0
+	nampla.mi_base, nampla.ma_base = 0, 0
+	nampla.pop_units = 0
+	nampla.item_quantity = [MAX_ITEMS]int{}
+	nampla.use_on_ambush = 0
+}
@@ -0,0 +1,328 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"fmt"
+	"strings"
+)
+
+var combat_action_name = [...]string{
+	DEFENSE_IN_PLACE:   "defense in place",
+	DEEP_SPACE_DEFENSE: "deep space defense",
+	PLANET_DEFENSE:     "planet defense",
+	DEEP_SPACE_FIGHT:   "deep space fight",
+	PLANET_ATTACK:      "planet attack",
+	PLANET_BOMBARDMENT: "planet bombardment",
+	GERM_WARFARE:       "germ warfare",
+	SIEGE:              "siege",
+}
+
+func (a combat_action_e) String() string {
+	if 0 <= a && int(a) < len(combat_action_name) {
+		return combat_action_name[a]
+	}
+	return fmt.Sprintf("combat_action_e(%d)", int(a))
+}
+
+// do_combat_orders records the battles requested in the COMBAT section of a species' orders.
+// The battles are fought when do_battles is called, after every species' orders have been recorded.
+func (g *galaxy_data_t) do_combat_orders(sp *species_data_t, orders []*order_t) {
+	var bat *battle_data_t
+	var i int
+	for _, o := range orders {
+		if o.command == BATTLE {
+			bat, i = g.do_battle_command(sp, o)
+			continue
+		}
+		if bat == nil {
+			sp.report.order_ignored(o, "A BATTLE order must precede this order.")
+			continue
+		}
+		switch o.command {
+		case ATTACK:
+			bat.do_attack_command(i, o)
+		case ENGAGE:
+			bat.do_engage_command(i, o)
+		case HAVEN:
+			bat.do_haven_command(i, o)
+		case HIJACK:
+			bat.do_hijack_command(i, o)
+		case SUMMARY:
+			bat.summary_only[i] = true
+		case TARGET:
+			bat.do_target_command(i, o)
+		case WITHDRAW:
+			bat.do_withdraw_command(i, o)
+		default:
+			sp.report.order_ignored(o, "Invalid combat command.")
+		}
+	}
+}
+
+// do_battle_command executes a BATTLE order:
+//
+//	BATTLE x y z
+//
+// Returns the battle at the location and the index of the species in it,
+// or nil if the order is invalid.
+func (g *galaxy_data_t) do_battle_command(sp *species_data_t, o *order_t) (*battle_data_t, int) {
+	var coords [3]int
+	for n := range coords {
+		value, ok := o.get_value()
+		if !ok {
+			sp.report.order_ignored(o, "Invalid or missing coordinates.")
+			return nil, 0
+		}
+		coords[n] = value
+	}
+	x, y, z := coords[0], coords[1], coords[2]
+	if !sp.is_present_at(x, y, z) {
+		sp.report.order_ignored(o, "You have no ships or populated planets at that location.")
+		return nil, 0
+	}
+	bat := g.find_battle(x, y, z)
+	if bat == nil {
+		if len(g.battles) >= MAX_BATTLES {
+			sp.report.order_ignored(o, "Too many battles this turn.")
+			return nil, 0
+		}
+		bat = g.new_battle(x, y, z)
+		g.battles = append(g.battles, bat)
+	}
+	return bat, bat.species_index(sp)
+}
+
+// find_battle returns the battle at the given location, or nil if there isn't one.
+func (g *galaxy_data_t) find_battle(x, y, z int) *battle_data_t {
+	for _, bat := range g.battles {
+		if bat.x == x && bat.y == y && bat.z == z {
+			return bat
+		}
+	}
+	return nil
+}
+
+// new_battle creates a battle at the given location. Every species present
+// takes part, and the enemies of each species are taken from its relations.
+// Species that don't give orders for the battle defend in place, have no
+// haven, withdraw transports as soon as they are damaged and never withdraw warships.
+func (g *galaxy_data_t) new_battle(x, y, z int) *battle_data_t {
+	bat := &battle_data_t{x: x, y: y, z: z}
+	for _, sp := range g.species {
+		if sp.is_present_at(x, y, z) && bat.num_species_here < MAX_SPECIES {
+			bat.spec_num[bat.num_species_here] = sp.id
+			bat.species[bat.num_species_here] = sp
+			bat.num_species_here++
+		}
+	}
+	for i := 0; i < bat.num_species_here; i++ {
+		for j := 0; j < bat.num_species_here; j++ {
+			bat.enemy_mine[i][j] = i != j && bat.species[i].is_enemy(bat.species[j])
+		}
+		bat.transport_withdraw_age[i], bat.warship_withdraw_age[i], bat.fleet_withdraw_percentage[i] = 0, 100, 100
+		bat.haven_x[i], bat.haven_y[i], bat.haven_z[i] = -1, -1, -1
+		bat.ambush_amount[i] = bat.species[i].ambush_at(x, y, z)
+		bat.can_be_surprised[i] = bat.species[i].just_jumped_tonnage(x, y, z) > 0
+	}
+	return bat
+}
+
+// species_index returns the index of the species in the battle.
+func (bat *battle_data_t) species_index(sp *species_data_t) int {
+	for i := 0; i < bat.num_species_here; i++ {
+		if bat.spec_num[i] == sp.id {
+			return i
+		}
+	}
+	panic(fmt.Sprintf("assert(species %d is in battle at %d %d %d)", sp.id, bat.x, bat.y, bat.z))
+}
+
+// is_present_at returns true if the species has a ship or a populated planet at the location.
+func (sp *species_data_t) is_present_at(x, y, z int) bool {
+	for _, ship := range sp.ships {
+		if ship.x == x && ship.y == y && ship.z == z && ship.can_fight() {
+			return true
+		}
+	}
+	for _, nampla := range sp.namplas {
+		if nampla.x == x && nampla.y == y && nampla.z == z && nampla.status&POPULATED != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// do_attack_command executes an ATTACK order:
+//
+//	ATTACK SP name
+//	ATTACK 0
+//
+// The species is treated as an enemy for this battle only. ATTACK 0 attacks
+// every species in the battle that isn't an ally.
+func (bat *battle_data_t) do_attack_command(i int, o *order_t) {
+	sp := bat.species[i]
+	if value, ok := o.get_value(); ok && value == 0 {
+		for j := 0; j < bat.num_species_here; j++ {
+			if j != i && !sp.is_ally(bat.species[j]) {
+				bat.enemy_mine[i][j] = true
+			}
+		}
+		return
+	}
+	if o.get_class_abbr() != SPECIES_ID {
+		sp.report.order_ignored(o, "Invalid or missing species.")
+		return
+	}
+	name := o.get_name()
+	for j := 0; j < bat.num_species_here; j++ {
+		if j == i || !strings.EqualFold(bat.species[j].name, name) {
+			continue
+		} else if sp.is_ally(bat.species[j]) {
+			sp.report.order_ignored(o, fmt.Sprintf("SP %s is an ally.", bat.species[j].name))
+			return
+		}
+		bat.enemy_mine[i][j] = true
+		return
+	}
+	sp.report.order_ignored(o, fmt.Sprintf("SP %s is not present at the battle.", name))
+}
+
+// do_engage_command executes an ENGAGE order:
+//
+//	ENGAGE option [planet_number]
+//
+// Planet attacks, bombardment, germ warfare and sieges need a planet number.
+func (bat *battle_data_t) do_engage_command(i int, o *order_t) {
+	sp := bat.species[i]
+	value, ok := o.get_value()
+	if !ok || value < int(DEFENSE_IN_PLACE) || value > int(SIEGE) {
+		sp.report.order_ignored(o, "Invalid or missing engagement option.")
+		return
+	} else if bat.num_engage_options[i] >= MAX_ENGAGE_OPTIONS {
+		sp.report.order_ignored(o, "Too many engagement options for this battle.")
+		return
+	}
+	option, pn := combat_action_e(value), 0
+	if option >= PLANET_ATTACK {
+		if pn, ok = o.get_value(); !ok || pn < 1 || pn > 9 {
+			sp.report.order_ignored(o, "Invalid or missing planet number.")
+			return
+		}
+	}
+	n := bat.num_engage_options[i]
+	bat.engage_option[i][n], bat.engage_planet[i][n] = option, pn
+	bat.num_engage_options[i]++
+}
+
+// has_option returns true if the species chose the engagement option.
+// For planet attacks, the option must also be aimed at the planet.
+func (bat *battle_data_t) has_option(i int, option combat_action_e, pn int) bool {
+	for n := 0; n < bat.num_engage_options[i]; n++ {
+		if bat.engage_option[i][n] == option && (option < PLANET_ATTACK || bat.engage_planet[i][n] == pn) {
+			return true
+		}
+	}
+	return false
+}
+
+// is_attacking returns true if the species chose an option that starts the given fight.
+// Bombardment, germ warfare and sieges all begin with an attack on the planet.
+func (bat *battle_data_t) is_attacking(i int, option combat_action_e, pn int) bool {
+	if option == DEEP_SPACE_FIGHT {
+		return bat.has_option(i, DEEP_SPACE_FIGHT, 0)
+	}
+	for n := 0; n < bat.num_engage_options[i]; n++ {
+		if bat.engage_option[i][n] >= PLANET_ATTACK && bat.engage_planet[i][n] == pn {
+			return true
+		}
+	}
+	return false
+}
+
+// are_enemies returns true if either species considers the other an enemy in this battle.
+func (bat *battle_data_t) are_enemies(i, j int) bool {
+	return bat.enemy_mine[i][j] || bat.enemy_mine[j][i]
+}
+
+// log_printf adds a line to the battle log of every species in the battle.
+// Observable arguments, such as unit names, are described as each species sees them.
+// Detailed lines are left out of the reports of species that asked for a summary.
+func (bat *battle_data_t) log_printf(detail bool, format string, args ...any) {
+	for i := 0; i < bat.num_species_here; i++ {
+		if detail && bat.summary_only[i] {
+			continue
+		}
+		bat.species[i].report.printf(format, observe(bat.species[i], args)...)
+	}
+}
+
+// do_battles fights every battle requested in the species' combat orders, in the order they were requested.
+func (g *galaxy_data_t) do_battles() {
+	for _, bat := range g.battles {
+		g.do_battle(bat)
+	}
+	g.battles = nil
+}
+
+// do_battle fights the battle at a location. Deep space combat comes first,
+// then the attacks on each planet in the system, each followed by any
+// bombardment, germ warfare or siege of the planet.
+// Species whose only presence is hidden colonies are not shown to the others,
+// and species whose only visible presence is disguised ships are not named.
+func (g *galaxy_data_t) do_battle(bat *battle_data_t) {
+	bat.log_printf(false, "\nBattle at x = %d, y = %d, z = %d:\n", bat.x, bat.y, bat.z)
+	for i := 0; i < bat.num_species_here; i++ {
+		for j := 0; j < bat.num_species_here; j++ {
+			switch {
+			case j == i:
+				bat.species[i].report.printf("  SP %s is present.\n", bat.species[j].name)
+			case bat.is_concealed(j):
+			case bat.is_disguised(j):
+				bat.species[i].report.printf("  An unidentified species is present.\n")
+			default:
+				bat.species[i].report.printf("  SP %s is present.\n", bat.species[j].name)
+			}
+		}
+	}
+	bat.check_surprise()
+
+	var destroyed []*ship_data_t
+	destroyed = append(destroyed, bat.fight(DEEP_SPACE_FIGHT, 0)...)
+	for pn := 1; pn <= 9; pn++ {
+		bat.report_hidden_colonies(pn)
+		destroyed = append(destroyed, bat.fight(PLANET_ATTACK, pn)...)
+		g.do_planet_actions(bat, pn)
+	}
+
+	if len(destroyed) == 0 {
+		bat.log_printf(false, "  No ships were destroyed.\n")
+	}
+	for i := 0; i < bat.num_species_here; i++ {
+		bat.species[i].remove_ships(destroyed)
+	}
+	g.resolve_captures(bat)
+}
+
+// remove_ships deletes any of the given ships that belong to the species.
+func (sp *species_data_t) remove_ships(ships []*ship_data_t) {
+	kept := sp.ships[:0]
+	for _, ship := range sp.ships {
+		lost := false
+		for _, s := range ships {
+			if s == ship {
+				lost = true
+				break
+			}
+		}
+		if lost {
+			sp.num_ships--
+		} else {
+			kept = append(kept, ship)
+		}
+	}
+	for n := len(kept); n < len(sp.ships); n++ {
+		sp.ships[n] = nil
+	}
+	sp.ships = kept
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+// Package combat runs the combat phases of a turn: the battles at the start
+// of the turn and the strikes after the ships have jumped.
+//
+// The battles are assembled from the BATTLE orders of species that are at the
+// same location as their enemies, and are fought round by round as the C
+// engine did. Every species in a battle gets a log of it in its report.
+// The results depend only on the orders and on the state of the random number
+// generator saved with the galaxy, so running the phase again from the same
+// database gives the same battles.
+package combat
+
+import (
+	"context"
+	"github.com/playbymail/fhgo"
+	"github.com/playbymail/fhgo/sqlc/sqlite3"
+)
+
+// Run loads the galaxy, fights the battles requested in the COMBAT section
+// of every species' orders and saves the result.
+func Run(ctx context.Context, q *sqlite3.Queries) error {
+	g, err := fhgo.LoadGalaxy(ctx, q)
+	if err != nil {
+		return err
+	}
+	if err := g.CombatOrders(ctx, q); err != nil {
+		return err
+	}
+	g.FightBattles()
+	return g.Save(ctx, q)
+}
+
+// RunStrikes loads the galaxy, fights the battles requested in the STRIKES
+// section of every species' orders and saves the result. Ships that jumped
+// in this turn can be surprised by enemies that prepared an ambush.
+func RunStrikes(ctx context.Context, q *sqlite3.Queries) error {
+	g, err := fhgo.LoadGalaxy(ctx, q)
+	if err != nil {
+		return err
+	}
+	if err := g.StrikeOrders(ctx, q); err != nil {
+		return err
+	}
+	g.FightBattles()
+	return g.Save(ctx, q)
+}
@@ -0,0 +1,306 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import "math"
+
+// power returns the combat power of a ship, which is its tonnage raised to the 1.2 power.
+func power(tonnage int) int64 {
+	return int64(math.Pow(float64(tonnage), 1.2))
+}
+
+// fight runs the rounds of deep space combat, or of an attack on a planet,
+// until no unit can fire at an enemy or the round limit is reached.
+// Returns the ships destroyed in the fight.
+func (bat *battle_data_t) fight(option combat_action_e, pn int) []*ship_data_t {
+	act := &action_data_t{}
+	if !bat.fighting_params(option, pn, act) {
+		return nil
+	}
+	if option == DEEP_SPACE_FIGHT {
+		bat.log_printf(false, "\n  Deep space combat:\n")
+	} else {
+		bat.log_printf(false, "\n  Attack on planet #%d:\n", pn)
+	}
+	for round_number := 1; round_number <= MAX_COMBAT_ROUNDS; round_number++ {
+		if !bat.do_round(round_number, act) {
+			break
+		}
+		bat.check_withdrawals(act)
+	}
+	bat.report_planet_defense_losses(act)
+
+	var destroyed []*ship_data_t
+	for unit := 0; unit < act.num_units_fighting; unit++ {
+		if act.is_destroyed(unit) {
+			destroyed = append(destroyed, act.fighting_unit[unit].(*ship_data_t))
+		}
+	}
+	return destroyed
+}
+
+// fighting_params chooses the units that take part in a fight and sets their
+// weapon and shield strengths.
+//
+// In a planet attack, the colonies on the planet fight with their PD units.
+//
+// Attackers bring all of their ships that aren't landed elsewhere. Enemies of
+// the attackers defend with the ships that are already where the fight is:
+// ships in deep space for deep space combat, and ships at the planet for a
+// planet attack. The DEEP_SPACE_DEFENSE and PLANET_DEFENSE options commit the
+// rest of a species' ships in the system.
+//
+// Returns false if there is no one to fight.
+func (bat *battle_data_t) fighting_params(option combat_action_e, pn int, act *action_data_t) bool {
+	var attacking [MAX_SPECIES]bool
+	any_attackers := false
+	for i := 0; i < bat.num_species_here; i++ {
+		attacking[i] = bat.is_attacking(i, option, pn)
+		any_attackers = any_attackers || attacking[i]
+	}
+	if !any_attackers {
+		return false
+	}
+
+	for i := 0; i < bat.num_species_here; i++ {
+		defending := false
+		for k := 0; k < bat.num_species_here; k++ {
+			defending = defending || (attacking[k] && k != i && bat.are_enemies(i, k))
+		}
+		if !attacking[i] && !defending {
+			continue
+		}
+		sp := bat.species[i]
+		for _, ship := range sp.ships {
+			if ship.x != bat.x || ship.y != bat.y || ship.z != bat.z || !ship.can_fight() || bat.is_captured(ship) {
+				continue
+			}
+			var fighting bool
+			switch {
+			case option == DEEP_SPACE_FIGHT && ship.status == ON_SURFACE:
+				fighting = false
+			case option == DEEP_SPACE_FIGHT:
+				fighting = attacking[i] || ship.status == IN_DEEP_SPACE ||
+					bat.has_option(i, DEEP_SPACE_DEFENSE, 0) || bat.has_option(i, DEEP_SPACE_FIGHT, 0)
+			case attacking[i] || ship.pn == pn:
+				fighting = ship.pn == pn || ship.status != ON_SURFACE
+			default:
+				fighting = ship.status != ON_SURFACE && bat.has_option(i, PLANET_DEFENSE, 0)
+			}
+			if !fighting || act.num_units_fighting >= MAX_SHIPS {
+				continue
+			}
+			act.add_ship(i, sp, ship, bat.can_be_surprised[i])
+		}
+
+		// colonies on the planet under attack defend it with their PDs
+		if option == DEEP_SPACE_FIGHT {
+			continue
+		}
+		for _, nampla := range sp.namplas {
+			if nampla.x != bat.x || nampla.y != bat.y || nampla.z != bat.z || nampla.pn != pn {
+				continue
+			} else if nampla.item_quantity[PD] == 0 || nampla.hidden || act.num_units_fighting >= MAX_SHIPS {
+				continue
+			}
+			act.add_nampla(i, sp, nampla)
+		}
+	}
+
+	// there must be at least one armed unit with an enemy to shoot at
+	for unit := 0; unit < act.num_units_fighting; unit++ {
+		if act.num_shots[unit] > 0 && act.find_targets(bat, unit) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// add_ship adds a ship to the units in a fight.
+// Weapons scale with the ship's power and the species' ML tech level, shields
+// with its power and LS tech level. Both lose two percent per year of age.
+// Auxiliary shield generators and gun units in the ship's cargo add to both.
+// Transports are not armed. Ships that just jumped in are surprised if their species was ambushed.
+func (act *action_data_t) add_ship(i int, sp *species_data_t, ship *ship_data_t, bat_surprised bool) {
+	unit := act.num_units_fighting
+	act.num_units_fighting++
+
+	act.fighting_species_index[unit] = i
+	act.unit_type[unit] = SHIP
+	act.fighting_unit[unit] = ship
+	act.original_age_or_PDs[unit] = int64(ship.age)
+	act.surprised[unit] = bat_surprised && ship.just_jumped
+
+	unit_power := power(ship.tonnage)
+	condition := int64(100 - 2*ship.age)
+	if ship.class != TR {
+		act.num_shots[unit] = 1
+		act.weapon_damage[unit] = (2 * int64(sp.tech_level[ML]) * unit_power * condition) / 1000
+	}
+	act.shield_strength[unit] = (int64(sp.tech_level[LS]) * unit_power * condition) / 1000
+	act.add_auxiliary_units(unit, sp, ship)
+	act.shield_strength_left[unit] = act.shield_strength[unit]
+}
+
+// add_auxiliary_units adds the auxiliary shield generators and gun units
+// carried by a ship to its shields and weapons. A Mark-n unit adds as much as
+// 50,000 tons of warship per mark would, scaled by the species' LS tech level
+// for shields and ML tech level for guns. Auxiliary units don't age with the
+// ship, and units of a mark above the species' tech level can't be used.
+// A transport carrying gun units is armed.
+func (act *action_data_t) add_auxiliary_units(unit int, sp *species_data_t, ship *ship_data_t) {
+	for mark := 1; mark <= 9; mark++ {
+		sg, gu := SG1+item_e(mark-1), GU1+item_e(mark-1)
+		unit_power := power(5 * mark)
+		if n := ship.item_quantity[sg]; n > 0 && sp.tech_level[LS] >= items[sg].min_level {
+			act.shield_strength[unit] += int64(n) * (int64(sp.tech_level[LS]) * unit_power) / 10
+		}
+		if n := ship.item_quantity[gu]; n > 0 && sp.tech_level[ML] >= items[gu].min_level {
+			act.num_shots[unit] = max(act.num_shots[unit], 1)
+			act.weapon_damage[unit] += int64(n) * (2 * int64(sp.tech_level[ML]) * unit_power) / 10
+		}
+	}
+}
+
+// is_out returns true if the unit has been destroyed, captured or has withdrawn from the fight.
+func (act *action_data_t) is_out(unit int) bool {
+	if act.unit_type[unit] == NONCOMBATANT {
+		return true
+	} else if ship, ok := act.fighting_unit[unit].(*ship_data_t); ok {
+		return !ship.can_fight()
+	} else if nampla, ok := act.fighting_unit[unit].(*nampla_data_t); ok {
+		return nampla.item_quantity[PD] == 0
+	}
+	return false
+}
+
+// is_destroyed returns true if the unit has been destroyed in the fight.
+func (act *action_data_t) is_destroyed(unit int) bool {
+	if ship, ok := act.fighting_unit[unit].(*ship_data_t); ok {
+		return ship.age > 49
+	}
+	return false
+}
+
+// find_targets returns the units that the unit may fire at.
+func (act *action_data_t) find_targets(bat *battle_data_t, unit int) []int {
+	var targets []int
+	i := act.fighting_species_index[unit]
+	for target := 0; target < act.num_units_fighting; target++ {
+		j := act.fighting_species_index[target]
+		if j != i && bat.are_enemies(i, j) && !act.is_out(target) {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// unit_name returns the name of a unit for the battle log.
+// Disguised ships are named differently for each species that reads the log.
+func (act *action_data_t) unit_name(bat *battle_data_t, unit int) observable {
+	sp := bat.species[act.fighting_species_index[unit]]
+	switch u := act.fighting_unit[unit].(type) {
+	case *ship_data_t:
+		return ship_view_t{ship: u, owner: sp}
+	case *nampla_data_t:
+		return plain_view_t("PL " + u.name + " of SP " + sp.name)
+	}
+	return plain_view_t("unknown unit of SP " + sp.name)
+}
+
+// do_round runs a single round of combat. Units fire one shot at a time, in random order, at enemies chosen by choose_target.
+// Shields are fully regenerated at the start of every round.
+// In the first round, surprised units can't fire and units of species whose ambush surprised an enemy fire first.
+// Returns false if no shots were fired, which ends the fight.
+func (bat *battle_data_t) do_round(round_number int, act *action_data_t) bool {
+	for unit := 0; unit < act.num_units_fighting; unit++ {
+		act.shots_left[unit] = act.num_shots[unit]
+		act.shield_strength_left[unit] = act.shield_strength[unit]
+		if round_number == 1 && act.surprised[unit] && act.num_shots[unit] > 0 {
+			act.shots_left[unit] = 0
+			bat.log_printf(true, "    %s was surprised and can't fire this round.\n", act.unit_name(bat, unit))
+		}
+	}
+
+	fired := false
+	for {
+		var ready, first_strike []int
+		for unit := 0; unit < act.num_units_fighting; unit++ {
+			if act.shots_left[unit] > 0 && !act.is_out(unit) {
+				ready = append(ready, unit)
+				if round_number == 1 && bat.first_strike[act.fighting_species_index[unit]] {
+					first_strike = append(first_strike, unit)
+				}
+			}
+		}
+		if len(first_strike) > 0 {
+			ready = first_strike
+		}
+		if len(ready) == 0 {
+			break
+		}
+		attacker := ready[rnd(len(ready))-1]
+		targets := act.find_targets(bat, attacker)
+		if len(targets) == 0 {
+			act.shots_left[attacker] = 0
+			continue
+		}
+		act.shots_left[attacker]--
+		if !fired {
+			bat.log_printf(true, "    Round %d:\n", round_number)
+			fired = true
+		}
+		bat.fire(act, attacker, bat.choose_target(act, attacker, targets))
+	}
+	return fired
+}
+
+// fire resolves a single shot. The odds of hitting depend on the ML tech
+// levels of the two species. Damage is absorbed by the target's shields
+// first; damage that gets through ages the ship. Ships older than 49 are
+// destroyed, unless the attacker is a hijacker, in which case they are captured.
+// Damage to a colony destroys its PD units instead.
+func (bat *battle_data_t) fire(act *action_data_t, attacker, target int) {
+	ml_attacker := bat.species[act.fighting_species_index[attacker]].tech_level[ML]
+	ml_defender := bat.species[act.fighting_species_index[target]].tech_level[ML]
+	chance_to_hit := 75
+	if ml_attacker+ml_defender > 0 {
+		chance_to_hit = (150 * ml_attacker) / (ml_attacker + ml_defender)
+	}
+
+	attacker_name, target_name := act.unit_name(bat, attacker), act.unit_name(bat, target)
+	if rnd(100) > chance_to_hit {
+		bat.log_printf(true, "      %s fires at %s and misses.\n", attacker_name, target_name)
+		return
+	}
+
+	damage := act.weapon_damage[attacker]
+	if damage <= act.shield_strength_left[target] {
+		act.shield_strength_left[target] -= damage
+		bat.log_printf(true, "      %s hits %s. The shields absorb all %d points of damage.\n", attacker_name, target_name, damage)
+		return
+	}
+	damage -= act.shield_strength_left[target]
+	act.shield_strength_left[target] = 0
+
+	if act.unit_type[target] == NAMPLA {
+		bat.log_printf(true, "      %s hits %s for %d points of damage.\n", attacker_name, target_name, damage)
+		bat.hit_planet_defenses(act, target, damage)
+		return
+	}
+	ship := act.fighting_unit[target].(*ship_data_t)
+	age_increase := int((damage * 2) / max(power(ship.tonnage), 1))
+	if age_increase < 1 {
+		age_increase = 1
+	}
+	ship.age += age_increase
+	ship.damage += age_increase
+	bat.log_printf(true, "      %s hits %s for %d points of damage.\n", attacker_name, target_name, damage)
+	if ship.age > 49 {
+		if bat.hijacker[act.fighting_species_index[attacker]] {
+			bat.capture(act, attacker, target)
+			return
+		}
+		bat.log_printf(false, "  %s was destroyed.\n", target_name)
+	}
+}
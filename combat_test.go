@@ -0,0 +1,102 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"github.com/playbymail/fhgo/prng"
+	"strings"
+	"testing"
+)
+
+// battle_galaxy returns a galaxy with two enemy fleets in deep space at 10 10 10.
+func battle_galaxy() *galaxy_data_t {
+	g := &galaxy_data_t{turn_number: 1}
+	for n, name := range []string{"Alpha", "Beta"} {
+		sp := &species_data_t{
+			id:      species_id_t(n + 1),
+			name:    name,
+			contact: map[species_id_t]bool{},
+			ally:    map[species_id_t]bool{},
+			enemy:   map[species_id_t]bool{},
+		}
+		sp.tech_level = [6]int{10, 10, 20 + 5*n, 10, 10, 10}
+		for _, class := range []ship_class_e{DD, CA, CA} {
+			sp.ships = append(sp.ships, &ship_data_t{
+				name:    ship_classes[class].abbr + "-" + name + "-" + string(rune('A'+len(sp.ships))),
+				x:       10,
+				y:       10,
+				z:       10,
+				status:  IN_DEEP_SPACE,
+				type_:   FTL,
+				class:   class,
+				tonnage: ship_classes[class].tonnage,
+			})
+		}
+		sp.num_ships = len(sp.ships)
+		g.species = append(g.species, sp)
+	}
+	alpha, beta := g.species[0], g.species[1]
+	alpha.contact[beta.id], alpha.enemy[beta.id] = true, true
+	beta.contact[alpha.id], beta.enemy[alpha.id] = true, true
+	return g
+}
+
+// fight_battle seeds the random number generator, fights the battle in
+// battle_galaxy and returns the species' reports.
+func fight_battle(t *testing.T, seed uint64) []string {
+	t.Helper()
+	orders, err := parse_orders(strings.NewReader("START COMBAT\nBattle 10 10 10\nEngage 3\nEND\n"))
+	if err != nil {
+		t.Fatalf("parse_orders: %v", err)
+	}
+	prng.SetSeed(seed)
+	g := battle_galaxy()
+	for _, sp := range g.species {
+		g.do_combat_orders(sp, orders[COMBAT_SECTION])
+	}
+	g.do_battles()
+	var reports []string
+	for _, sp := range g.species {
+		reports = append(reports, sp.report.String())
+	}
+	return reports
+}
+
+func TestBattleIsDeterministic(t *testing.T) {
+	const seed = 1924085713
+	first, second := fight_battle(t, seed), fight_battle(t, seed)
+	for n := range first {
+		if !strings.Contains(first[n], "Battle at x = 10, y = 10, z = 10") {
+			t.Fatalf("species %d: no battle in report:\n%s", n+1, first[n])
+		} else if first[n] != second[n] {
+			t.Errorf("species %d: battle logs differ with the same seed:\n--- first\n%s\n--- second\n%s", n+1, first[n], second[n])
+		}
+	}
+
+	if other := fight_battle(t, seed+1); other[0] == first[0] && other[1] == first[1] {
+		t.Errorf("battle logs are the same with a different seed")
+	}
+}
+
+func TestAmbushNeedsSurpriseForFirstStrike(t *testing.T) {
+	prng.SetSeed(1924085713)
+	g := battle_galaxy()
+	alpha, beta := g.species[0], g.species[1]
+	alpha.namplas = append(alpha.namplas, &nampla_data_t{id: 1, name: "Trap", x: 10, y: 10, z: 10, pn: 1, status: COLONY | POPULATED, use_on_ambush: 10_000})
+
+	// no ships jumped in, so there is no one to surprise
+	bat := g.new_battle(10, 10, 10)
+	bat.check_surprise()
+	if bat.first_strike[0] {
+		t.Errorf("ambush without surprise: got first strike, want none")
+	}
+
+	for _, ship := range beta.ships {
+		ship.just_jumped = true
+	}
+	bat = g.new_battle(10, 10, 10)
+	bat.check_surprise()
+	if !bat.can_be_surprised[1] || !bat.first_strike[0] {
+		t.Errorf("ambush with surprise: got surprised %v, first strike %v, want both", bat.can_be_surprised[1], bat.first_strike[0])
+	}
+}
@@ -10,6 +10,7 @@ const (
 	/* Minimum and maximum values for a galaxy. */
 
 	MAX_BATTLES              = 50 /* Maximum number of battle locations for all players. */
+	MAX_COMBAT_ROUNDS        = 10 /* Maximum number of rounds in a single fight. */
 	MAX_DIAMETER             = MAX_RADIUS * 2
 	MAX_ENGAGE_OPTIONS       = 20 /* Maximum number of engagement options that a player may specify for a single battle. */
 	MAX_INTERCEPTS           = 1_000
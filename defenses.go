@@ -0,0 +1,166 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import "fmt"
+
+// install_starbase_units executes the starbase form of an INSTALL order:
+//
+//	INSTALL [quantity] SU BA name
+//
+// Each SU unit adds 10,000 tons to the starbase. The units are taken from the
+// starbase's own cargo first, then from the species' colony on the planet the
+// starbase orbits. The starbase can't grow beyond the tonnage that the
+// species' tech level allows. If the quantity is missing or zero, all the
+// available units are installed.
+func (sp *species_data_t) install_starbase_units(o *order_t, quantity int) {
+	if o.get_class_abbr() != SHIP_CLASS || ship_class_e(o.abbr_index) != BA {
+		sp.report.order_ignored(o, "SUs may only be installed in a starbase.")
+		return
+	}
+	name := o.get_name()
+	ship := sp.find_ship(name)
+	if ship == nil || ship.class != BA {
+		sp.report.order_ignored(o, fmt.Sprintf("You do not have a starbase named %q.", name))
+		return
+	} else if ship.status == UNDER_CONSTRUCTION {
+		sp.report.order_ignored(o, fmt.Sprintf("%s is still under construction.", ship.ship_name()))
+		return
+	}
+
+	var nampla *nampla_data_t
+	if ship.status == IN_ORBIT {
+		for _, n := range sp.namplas {
+			if n.x == ship.x && n.y == ship.y && n.z == ship.z && n.pn == ship.pn && n.status&DISBANDED_COLONY == 0 {
+				nampla = n
+				break
+			}
+		}
+	}
+	available := ship.item_quantity[SU]
+	if nampla != nil {
+		available += nampla.item_quantity[SU]
+	}
+	if quantity == 0 {
+		quantity = available
+	} else if quantity > available {
+		sp.report.order_ignored(o, fmt.Sprintf("Only %s are available to %s.", item_quantity_name(SU, available), ship.ship_name()))
+		return
+	}
+	if quantity == 0 {
+		sp.report.order_ignored(o, fmt.Sprintf("There are no SUs available to %s.", ship.ship_name()))
+		return
+	}
+
+	sc := ship_classes[BA]
+	max_tonnage := sp.tech_level[sc.tech] / max(sc.min_level, 1)
+	if ship.tonnage+quantity > max_tonnage {
+		quantity = max(max_tonnage-ship.tonnage, 0)
+	}
+	if quantity == 0 {
+		sp.report.order_ignored(o, fmt.Sprintf("Your %s tech level is too low for %s to grow any larger.", tech_abbr[sc.tech], ship.ship_name()))
+		return
+	}
+
+	old_name := ship.ship_name()
+	from_cargo := min(quantity, ship.item_quantity[SU])
+	ship.item_quantity[SU] -= from_cargo
+	if nampla != nil {
+		nampla.item_quantity[SU] -= quantity - from_cargo
+	}
+	ship.tonnage += quantity
+	sp.report.printf("Installed %s in %s. It is now %s, of %d tons.\n",
+		item_quantity_name(SU, quantity), old_name, ship.ship_name(), ship.tonnage*10_000)
+}
+
+// add_nampla adds a colony's planetary defenses to the units in a fight.
+// Only colonies with PD units can fight.
+func (act *action_data_t) add_nampla(i int, sp *species_data_t, nampla *nampla_data_t) {
+	unit := act.num_units_fighting
+	act.num_units_fighting++
+
+	act.fighting_species_index[unit] = i
+	act.unit_type[unit] = NAMPLA
+	act.fighting_unit[unit] = nampla
+	act.original_age_or_PDs[unit] = int64(nampla.item_quantity[PD])
+	act.set_planet_defenses(unit, sp, nampla.item_quantity[PD])
+	act.shield_strength_left[unit] = act.shield_strength[unit]
+}
+
+// set_planet_defenses sets the weapons and shields of a colony's planetary
+// defenses. Two PD units fight like 10,000 tons of warship in perfect
+// condition, with weapons that scale with the species' ML tech level and
+// shields with its LS tech level.
+func (act *action_data_t) set_planet_defenses(unit int, sp *species_data_t, pds int) {
+	act.num_shots[unit] = 0
+	act.weapon_damage[unit], act.shield_strength[unit] = 0, 0
+	if pds <= 0 {
+		return
+	}
+	unit_power := power((pds + 1) / 2)
+	act.num_shots[unit] = 1
+	act.weapon_damage[unit] = (2 * int64(sp.tech_level[ML]) * unit_power) / 10
+	act.shield_strength[unit] = (int64(sp.tech_level[LS]) * unit_power) / 10
+	act.shield_strength_left[unit] = min(act.shield_strength_left[unit], act.shield_strength[unit])
+}
+
+// hit_planet_defenses applies the damage that got through a colony's shields.
+// Damage that would age a ship by a year destroys a fiftieth of the PD units
+// the colony started the fight with, and every hit destroys at least one.
+// The colony's weapons and shields shrink with its defenses.
+func (bat *battle_data_t) hit_planet_defenses(act *action_data_t, target int, damage int64) {
+	nampla := act.fighting_unit[target].(*nampla_data_t)
+	sp := bat.species[act.fighting_species_index[target]]
+	original := act.original_age_or_PDs[target]
+	lost := int((damage * 2 * original) / (50 * max(power(int((original+1)/2)), 1)))
+	lost = min(max(lost, 1), nampla.item_quantity[PD])
+	nampla.item_quantity[PD] -= lost
+	act.set_planet_defenses(target, sp, nampla.item_quantity[PD])
+	if nampla.item_quantity[PD] == 0 {
+		bat.log_printf(false, "  The planetary defenses of PL %s of SP %s were destroyed.\n", nampla.name, sp.name)
+	}
+}
+
+// report_planet_defense_losses adds the PD units each colony lost in a fight to the battle log.
+func (bat *battle_data_t) report_planet_defense_losses(act *action_data_t) {
+	for unit := 0; unit < act.num_units_fighting; unit++ {
+		nampla, ok := act.fighting_unit[unit].(*nampla_data_t)
+		if !ok || act.unit_type[unit] != NAMPLA {
+			continue
+		}
+		if lost := int(act.original_age_or_PDs[unit]) - nampla.item_quantity[PD]; lost > 0 {
+			bat.log_printf(false, "  PL %s of SP %s lost %s.\n", nampla.name, bat.species[act.fighting_species_index[unit]].name, item_quantity_name(PD, lost))
+		}
+	}
+}
+
+// report_defenses adds the species' planetary defenses and starbases to its report.
+func (sp *species_data_t) report_defenses() {
+	header := false
+	for _, nampla := range sp.namplas {
+		pds := nampla.item_quantity[PD]
+		if pds == 0 || nampla.status&DISBANDED_COLONY != 0 {
+			continue
+		}
+		if !header {
+			sp.report.printf("\nPlanetary defenses:\n")
+			header = true
+		}
+		act := &action_data_t{}
+		act.set_planet_defenses(0, sp, pds)
+		sp.report.printf("  PL %-20s %6d PDs  weapons %6d  shields %6d\n", nampla.name, pds, act.weapon_damage[0], act.shield_strength[0])
+	}
+
+	header = false
+	for _, ship := range sp.ships {
+		if ship.class != BA || ship.status == UNDER_CONSTRUCTION {
+			continue
+		}
+		if !header {
+			sp.report.printf("\nStarbases:\n")
+			header = true
+		}
+		sp.report.printf("  %-24s %9d tons  at x = %d, y = %d, z = %d, planet #%d\n",
+			ship.ship_name(), ship.tonnage*10_000, ship.x, ship.y, ship.z, ship.pn)
+	}
+}
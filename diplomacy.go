@@ -0,0 +1,244 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/playbymail/fhgo/sqlc/sqlite3"
+	"io"
+	"strings"
+)
+
+// is_ally returns true if the species considers the other species an ally.
+func (sp *species_data_t) is_ally(alien *species_data_t) bool {
+	return sp.ally[alien.id]
+}
+
+// is_enemy returns true if the species considers the other species an enemy.
+func (sp *species_data_t) is_enemy(alien *species_data_t) bool {
+	return sp.enemy[alien.id]
+}
+
+// set_relation makes the other species an ally, an enemy or neutral.
+// The command is one of ALLY, ENEMY or NEUTRAL.
+func (sp *species_data_t) set_relation(id species_id_t, relation command_code_e) {
+	if sp.ally == nil {
+		sp.ally = map[species_id_t]bool{}
+	}
+	if sp.enemy == nil {
+		sp.enemy = map[species_id_t]bool{}
+	}
+	sp.ally[id] = relation == ALLY
+	sp.enemy[id] = relation == ENEMY
+}
+
+// make_contact records that two species have met.
+// Returns true if they had not met before.
+func (sp *species_data_t) make_contact(alien *species_data_t) bool {
+	if sp.contact == nil {
+		sp.contact = map[species_id_t]bool{}
+	}
+	if sp.contact[alien.id] {
+		return false
+	}
+	sp.contact[alien.id] = true
+	return true
+}
+
+// do_diplomacy_command executes an ALLY, ENEMY or NEUTRAL order:
+//
+//	ALLY SP name
+//	ENEMY SP name
+//	ENEMY 0
+//	NEUTRAL SP name
+//	NEUTRAL 0
+//
+// A species must be met before it can be named. ENEMY 0 and NEUTRAL 0 apply
+// to every other species, including those not yet met. Declaring a species
+// an ally or an enemy replaces any earlier declaration.
+func (g *galaxy_data_t) do_diplomacy_command(sp *species_data_t, o *order_t) {
+	relation := o.command
+	if value, ok := o.get_value(); ok {
+		if value != 0 || relation == ALLY {
+			sp.report.order_ignored(o, "Invalid or missing species.")
+			return
+		}
+		for _, alien := range g.species {
+			if alien != sp {
+				sp.set_relation(alien.id, relation)
+			}
+		}
+		if relation == ENEMY {
+			sp.report.printf("All species are now considered enemies.\n")
+		} else {
+			sp.report.printf("All species are now considered neutral.\n")
+		}
+		return
+	}
+	alien, reason := g.get_contacted_species(sp, o)
+	if reason != "" {
+		sp.report.order_ignored(o, reason)
+		return
+	}
+	sp.set_relation(alien.id, relation)
+	switch relation {
+	case ALLY:
+		sp.report.printf("SP %s is now considered an ally.\n", alien.name)
+	case ENEMY:
+		sp.report.printf("SP %s is now considered an enemy.\n", alien.name)
+	default:
+		sp.report.printf("SP %s is now considered neutral.\n", alien.name)
+	}
+}
+
+// update_contacts records first contact between species that have ships or
+// colonies in the same star system. Hidden colonies aren't noticed.
+func (g *galaxy_data_t) update_contacts() {
+	for _, star := range g.stars {
+		var here []*species_data_t
+		for _, sp := range g.species {
+			if sp.is_visible_at(star.x, star.y, star.z) {
+				here = append(here, sp)
+			}
+		}
+		for _, sp := range here {
+			for _, alien := range here {
+				if alien != sp && sp.make_contact(alien) {
+					sp.report.printf("\nYou have made contact with SP %s at x = %d, y = %d, z = %d.\n", alien.name, star.x, star.y, star.z)
+				}
+			}
+		}
+	}
+}
+
+// is_visible_at returns true if other species at the location can see the species.
+func (sp *species_data_t) is_visible_at(x, y, z int) bool {
+	for _, ship := range sp.ships {
+		if ship.x == x && ship.y == y && ship.z == z && ship.status != UNDER_CONSTRUCTION {
+			return true
+		}
+	}
+	for _, nampla := range sp.namplas {
+		if nampla.x == x && nampla.y == y && nampla.z == z && nampla.status&POPULATED != 0 && !nampla.hidden && !nampla.hiding {
+			return true
+		}
+	}
+	return false
+}
+
+// save_relations updates the current relations of every species and adds them to the history for the turn.
+func (g *galaxy_data_t) save_relations(ctx context.Context, q *sqlite3.Queries) error {
+	if err := q.DeleteSpeciesRelations(ctx, int64(g.turn_number)); err != nil {
+		return err
+	}
+	for _, sp := range g.species {
+		for _, alien := range g.species {
+			if alien == sp {
+				continue
+			}
+			contact, ally, enemy := boolToInt64(sp.contact[alien.id]), boolToInt64(sp.is_ally(alien)), boolToInt64(sp.is_enemy(alien))
+			err := q.UpsertSpeciesContact(ctx, sqlite3.UpsertSpeciesContactParams{
+				SpeciesID: int64(sp.id),
+				AlienID:   int64(alien.id),
+				Contact:   contact,
+				Ally:      ally,
+				Enemy:     enemy,
+			})
+			if err != nil {
+				return err
+			}
+			err = q.CreateSpeciesRelation(ctx, sqlite3.CreateSpeciesRelationParams{
+				TurnNumber: int64(g.turn_number),
+				SpeciesID:  int64(sp.id),
+				AlienID:    int64(alien.id),
+				Contact:    contact,
+				Ally:       ally,
+				Enemy:      enemy,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// load_relations reads the current relations of the species.
+func (sp *species_data_t) load_relations(ctx context.Context, q *sqlite3.Queries) error {
+	rows, err := q.ListSpeciesContacts(ctx, int64(sp.id))
+	if err != nil {
+		return err
+	}
+	sp.contact = map[species_id_t]bool{}
+	sp.ally = map[species_id_t]bool{}
+	sp.enemy = map[species_id_t]bool{}
+	for _, row := range rows {
+		id := species_id_t(row.AlienID)
+		sp.contact[id], sp.ally[id], sp.enemy[id] = row.Contact != 0, row.Ally != 0, row.Enemy != 0
+	}
+	return nil
+}
+
+// ShowRelations writes the matrix of relations between species recorded for a turn.
+// If the turn is zero, the last turn recorded is shown.
+//
+// Each row is a species and each column is the species it has relations with:
+// A is an ally, E is an enemy, N is neutral and - means they haven't met.
+func ShowRelations(ctx context.Context, q *sqlite3.Queries, w io.Writer, turn int) error {
+	if turn == 0 {
+		latest, err := q.GetLatestRelationsTurn(ctx)
+		if errors.Is(err, sql.ErrNoRows) {
+			_, err = fmt.Fprintf(w, "No relations have been recorded.\n")
+			return err
+		} else if err != nil {
+			return err
+		}
+		turn = int(latest)
+	}
+	rows, err := q.ListSpeciesRelations(ctx, int64(turn))
+	if err != nil {
+		return err
+	}
+	var ids []int64
+	cells := map[[2]int64]byte{}
+	for _, row := range rows {
+		if len(ids) == 0 || ids[len(ids)-1] != row.SpeciesID {
+			ids = append(ids, row.SpeciesID)
+		}
+		cell := byte('-')
+		switch {
+		case row.Ally != 0:
+			cell = 'A'
+		case row.Enemy != 0:
+			cell = 'E'
+		case row.Contact != 0:
+			cell = 'N'
+		}
+		cells[[2]int64{row.SpeciesID, row.AlienID}] = cell
+	}
+
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "Relations at the end of turn %d:\n\n  SP", turn)
+	for _, id := range ids {
+		fmt.Fprintf(sb, " %3d", id)
+	}
+	sb.WriteString("\n")
+	for _, id := range ids {
+		fmt.Fprintf(sb, "%4d", id)
+		for _, alien := range ids {
+			cell, ok := cells[[2]int64{id, alien}]
+			if id == alien {
+				cell = '.'
+			} else if !ok {
+				cell = '-'
+			}
+			fmt.Fprintf(sb, "   %c", cell)
+		}
+		sb.WriteString("\n")
+	}
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
@@ -181,6 +181,11 @@ const (
 	LANDING_REQUEST
 	LOOTING_EU_TRANSFER
 	ALLIES_ORDER
+	ITEM_TRANSFER
+	SHIP_UPGRADE
+	SHIP_RECYCLE
+	ITEM_RECYCLE
+	COLONY_DISBANDED
 )
 
 // Status codes for named planets. These are logically ORed together.
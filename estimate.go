@@ -0,0 +1,81 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ESTIMATE_COST is the cost in EUs of estimating another species' tech levels.
+const ESTIMATE_COST = 25
+
+// tech_estimates_t holds the tech levels estimated during a turn, keyed by
+// the estimating species and the alien species.
+type tech_estimates_t map[[2]species_id_t][6]int
+
+// estimate_margin returns the error margin, as a percentage, of the species'
+// estimates of alien tech levels. It shrinks as the species' military and
+// gravitics tech levels rise, but never below five percent.
+func (sp *species_data_t) estimate_margin() int {
+	return max(50-(sp.tech_level[ML]+sp.tech_level[GV])/4, 5)
+}
+
+// estimate_tech_levels returns the species' estimate of the alien's tech
+// levels. Each level is off by up to the species' estimate_margin. The
+// estimate is recorded, so estimating the same alien again in the turn gives
+// the same result.
+func (g *galaxy_data_t) estimate_tech_levels(sp, alien *species_data_t) [6]int {
+	key := [2]species_id_t{sp.id, alien.id}
+	if estimate, ok := g.estimates[key]; ok {
+		return estimate
+	}
+	margin := sp.estimate_margin()
+	var estimate [6]int
+	for tech, level := range alien.tech_level {
+		max_error := (level * margin) / 100
+		estimate[tech] = max(level+rnd(2*max_error+1)-max_error-1, 0)
+	}
+	if g.estimates == nil {
+		g.estimates = tech_estimates_t{}
+	}
+	g.estimates[key] = estimate
+	return estimate
+}
+
+// do_estimate_command executes an ESTIMATE order:
+//
+//	ESTIMATE
+//	ESTIMATE SP name
+//
+// The first form tells the species what its fleet will cost to maintain at
+// the end of the turn, based on the ships it has now. The second form spends
+// ESTIMATE_COST to estimate the tech levels of a species it has met. An alien
+// is only charged for once a turn; estimating it again repeats the estimate.
+func (g *galaxy_data_t) do_estimate_command(p *production_t, o *order_t) {
+	sp := p.species
+	if !o.remaining() {
+		sp.update_fleet_cost()
+		sp.report.printf("Projected fleet maintenance cost is %d (%d.%02d%% of total production), with %d in the treasury.\n",
+			sp.fleet_cost, sp.fleet_percent_cost/100, sp.fleet_percent_cost%100, sp.econ_units)
+		return
+	}
+
+	alien, reason := g.get_contacted_species(sp, o)
+	if reason != "" {
+		sp.report.order_ignored(o, reason)
+		return
+	}
+	if _, ok := g.estimates[[2]species_id_t{sp.id, alien.id}]; !ok {
+		if p.check_bounced(ESTIMATE_COST) {
+			sp.report.order_ignored(o, fmt.Sprintf("Insufficient funds. The order needs %d.", ESTIMATE_COST))
+			return
+		}
+	}
+	estimate := g.estimate_tech_levels(sp, alien)
+	levels := make([]string, len(estimate))
+	for tech, level := range estimate {
+		levels[tech] = fmt.Sprintf("%s %d", tech_abbr[tech], level)
+	}
+	sp.report.printf("Estimated tech levels of SP %s (to within %d%%): %s.\n", alien.name, sp.estimate_margin(), strings.Join(levels, ", "))
+}
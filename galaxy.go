@@ -13,10 +13,22 @@ import (
 type GalaxyData = galaxy_data_t
 
 type galaxy_data_t struct {
-	d_num_species int // Design number of species in galaxy
-	num_species   int // Actual number of species allocated
-	radius        int // Galactic radius in parsecs
-	turn_number   int // Current turn number
+	d_num_species      int // Design number of species in galaxy
+	num_species        int // Actual number of species allocated
+	radius             int // Galactic radius in parsecs
+	turn_number        int // Current turn number
+	species            []*species_data_t
+	stars              []*star_data_t
+	battles            []*battle_data_t        // battles requested in the species' combat orders
+	transactions       []*trans_data_t         // interspecies transactions made during the turn
+	messages           []*message_data_t       // messages sent between species during the turn
+	planet_history     []*planet_history_t     // changes made to planets during the turn
+	population_history []*population_history_t // population of each colony at the end of the turn
+	portal_tonnage     map[*ship_data_t]int    // tonnage sent through each jump portal during the turn
+	estimates          tech_estimates_t        // tech levels estimated during the turn, by estimating species and alien
+	auto_orders        map[species_id_t]string // orders generated for the next turn for species that gave the AUTO command
+	phases             map[string]bool         // phases of the turn that have already been run
+	phase              string                  // phase being run, recorded when the turn is saved
 }
 
 func CreateGalaxy(path string, galacticRadius, desiredNumStars, desiredNumSpecies int, seed uint64) *GalaxyData {
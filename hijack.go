@@ -0,0 +1,127 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import "fmt"
+
+// capture_t records a ship captured by a hijacker during a battle.
+type capture_t struct {
+	ship      *ship_data_t
+	victim    int    // index of the ship's original owner in the battle
+	hijacker  int    // index of the capturing species in the battle
+	ship_name string // name of the ship when it was captured
+}
+
+// do_hijack_command executes a HIJACK order:
+//
+//	HIJACK SP name
+//	HIJACK 0
+//
+// The species attacks as with an ATTACK order, but tries to capture enemy
+// ships instead of destroying them.
+func (bat *battle_data_t) do_hijack_command(i int, o *order_t) {
+	bat.do_attack_command(i, o)
+	for j := 0; j < bat.num_species_here; j++ {
+		if bat.enemy_mine[i][j] {
+			bat.hijacker[i] = true
+		}
+	}
+}
+
+// is_captured returns true if the ship was captured earlier in the battle.
+// Captured ships take no further part in the battle.
+func (bat *battle_data_t) is_captured(ship *ship_data_t) bool {
+	for _, c := range bat.captures {
+		if c.ship == ship {
+			return true
+		}
+	}
+	return false
+}
+
+// capture takes a ship that a hijacker's shot would have destroyed. The ship
+// is left badly damaged and out of the fight; it changes hands when the battle is over.
+func (bat *battle_data_t) capture(act *action_data_t, attacker, target int) {
+	ship := act.fighting_unit[target].(*ship_data_t)
+	ship.damage += max(49-ship.age, 0)
+	ship.age = 49
+	act.unit_type[target] = NONCOMBATANT
+	bat.captures = append(bat.captures, capture_t{
+		ship:      ship,
+		victim:    act.fighting_species_index[target],
+		hijacker:  act.fighting_species_index[attacker],
+		ship_name: ship.ship_name(),
+	})
+	bat.log_printf(false, "  %s was captured by SP %s!\n", act.unit_name(bat, target), bat.species[act.fighting_species_index[attacker]].name)
+}
+
+// resolve_captures moves each captured ship, with its cargo, to the
+// hijacker's fleet. A ship whose name the hijacker already uses is renamed
+// with a numbered suffix. Cargo that the hijacker doesn't have the tech to
+// use is sold off as loot for half its cost, which is recorded as a
+// LOOTING_EU_TRANSFER transaction. If the ledger is full, nothing is looted
+// and the cargo stays on the ship.
+func (g *galaxy_data_t) resolve_captures(bat *battle_data_t) {
+	for _, c := range bat.captures {
+		victim, hijacker := bat.species[c.victim], bat.species[c.hijacker]
+		ship := c.ship
+		victim.remove_ships([]*ship_data_t{ship})
+		ship.loading_point, ship.unloading_point = 0, 0
+		ship.special = 0
+		renamed := hijacker.find_ship(ship.name) != nil
+		if renamed {
+			ship.name = hijacker.unused_ship_name(ship.name)
+		}
+		hijacker.ships = append(hijacker.ships, ship)
+		hijacker.num_ships++
+
+		// loot is only taken when the transfer can be recorded in the ledger
+		loot, can_loot := 0, !g.ledger_full()
+		for item, quantity := range ship.item_quantity {
+			it := items[item]
+			if !can_loot || quantity == 0 || !it.defined() || hijacker.tech_level[it.tech] >= it.min_level {
+				continue
+			}
+			loot += (quantity * it.cost) / 2
+			ship.item_quantity[item] = 0
+		}
+
+		victim.report.printf("\n%s was captured by SP %s, along with its cargo.\n", c.ship_name, hijacker.name)
+		if renamed {
+			hijacker.report.printf("\nYou captured %s from SP %s. You already have a ship with that name, so it has been renamed %s and added to your fleet.\n",
+				c.ship_name, victim.name, ship.ship_name())
+		} else {
+			hijacker.report.printf("\nYou captured %s from SP %s. It has been added to your fleet.\n", c.ship_name, victim.name)
+		}
+		hijacker.report_inventory(cargo_holder_t{ship: ship})
+		if loot == 0 {
+			continue
+		}
+		g.add_transaction(&trans_data_t{
+			type_:     LOOTING_EU_TRANSFER,
+			donor:     victim.id,
+			recipient: hijacker.id,
+			value:     loot,
+			x:         bat.x,
+			y:         bat.y,
+			z:         bat.z,
+			pn:        ship.pn,
+			name1:     ship.name,
+		})
+		hijacker.econ_units += loot
+		hijacker.report.printf("Cargo you can't use was sold off as loot for %d economic units.\n", loot)
+		victim.report.printf("SP %s looted %d economic units from its cargo.\n", hijacker.name, loot)
+	}
+	bat.captures = nil
+}
+
+// unused_ship_name returns the name with the lowest numbered suffix, starting
+// at 2, that none of the species' ships has.
+func (sp *species_data_t) unused_ship_name(name string) string {
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s %d", name, n)
+		if sp.find_ship(candidate) == nil {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// find_species_by_name returns the species with the given name, or nil if there isn't one.
+func (g *galaxy_data_t) find_species_by_name(name string) *species_data_t {
+	for _, sp := range g.species {
+		if strings.EqualFold(sp.name, name) {
+			return sp
+		}
+	}
+	return nil
+}
+
+// get_contacted_species parses "SP name" and returns the named species.
+// Returns an error message if the species is missing or hasn't been met.
+func (g *galaxy_data_t) get_contacted_species(sp *species_data_t, o *order_t) (*species_data_t, string) {
+	if o.get_class_abbr() != SPECIES_ID {
+		return nil, "Invalid or missing species."
+	}
+	name := o.get_name()
+	alien := g.find_species_by_name(name)
+	if alien == nil || alien == sp {
+		return nil, fmt.Sprintf("There is no species named %q.", name)
+	} else if !sp.contact[alien.id] {
+		return nil, fmt.Sprintf("You have not made contact with SP %s.", alien.name)
+	}
+	return alien, ""
+}
+
+// do_send_command executes a SEND order:
+//
+//	SEND amount SP name
+//
+// The economic units are taken from the current production and added to the other species' treasury.
+func (g *galaxy_data_t) do_send_command(p *production_t, o *order_t) {
+	sp := p.species
+	amount, ok := o.get_value()
+	if !ok || amount <= 0 {
+		sp.report.order_ignored(o, "Invalid or missing amount to send.")
+		return
+	}
+	alien, reason := g.get_contacted_species(sp, o)
+	if reason != "" {
+		sp.report.order_ignored(o, reason)
+		return
+	}
+	if g.ledger_full() {
+		sp.report.order_ignored(o, "Too many transactions this turn.")
+		return
+	}
+	if p.check_bounced(amount) {
+		sp.report.order_ignored(o, "Insufficient funds to execute order.")
+		return
+	}
+	g.add_transaction(&trans_data_t{type_: EU_TRANSFER, donor: sp.id, recipient: alien.id, value: amount})
+	alien.econ_units += amount
+	sp.report.printf("Sent %d economic units to SP %s.\n", amount, alien.name)
+}
+
+// do_teach_command executes a TEACH order:
+//
+//	TEACH tech [level] SP name
+//
+// The other species learns the tech up to the given level, or up to the
+// teacher's current level if no level is given. The knowledge is passed on
+// at the end of the turn and must be applied through research.
+func (g *galaxy_data_t) do_teach_command(sp *species_data_t, o *order_t) {
+	if o.get_class_abbr() != TECH_ID {
+		sp.report.order_ignored(o, "Invalid or missing technology.")
+		return
+	}
+	tech := tech_level_e(o.abbr_index)
+	level, ok := o.get_value()
+	if !ok {
+		level = sp.tech_level[tech]
+	} else if level <= 0 || level > sp.tech_level[tech] {
+		sp.report.order_ignored(o, fmt.Sprintf("You can't teach %s beyond your own level of %d.", tech, sp.tech_level[tech]))
+		return
+	}
+	alien, reason := g.get_contacted_species(sp, o)
+	if reason != "" {
+		sp.report.order_ignored(o, reason)
+		return
+	}
+	t := &trans_data_t{type_: TECH_TRANSFER, donor: sp.id, recipient: alien.id, value: level, number1: int(tech)}
+	if !g.add_transaction(t) {
+		sp.report.order_ignored(o, "Too many transactions this turn.")
+		return
+	}
+	sp.report.printf("SP %s will be taught %s up to level %d.\n", alien.name, tech, level)
+}
+
+// get_alien_cargo_holder parses a named planet or a ship of another species, for use as a transfer destination.
+func (alien *species_data_t) get_alien_cargo_holder(o *order_t) (cargo_holder_t, string) {
+	switch o.get_class_abbr() {
+	case PLANET_ID:
+		name := o.get_name()
+		if nampla := alien.find_nampla(name); nampla != nil && nampla.status&POPULATED != 0 {
+			return cargo_holder_t{nampla: nampla}, ""
+		}
+		return cargo_holder_t{}, fmt.Sprintf("SP %s does not have a colony named %q.", alien.name, name)
+	case SHIP_CLASS:
+		name := o.get_name()
+		if ship := alien.find_ship(name); ship != nil && ship.status != UNDER_CONSTRUCTION {
+			return cargo_holder_t{ship: ship}, ""
+		}
+		return cargo_holder_t{}, fmt.Sprintf("SP %s does not have a ship named %q.", alien.name, name)
+	}
+	return cargo_holder_t{}, "Invalid or missing planet or ship."
+}
+
+// do_land_command executes a LAND order:
+//
+//	LAND ship
+//
+// A ship in orbit lands on the planet. If another species has a colony on
+// the planet, it lands only if that species considers the lander an ally.
+// Every landing on an alien colony is recorded as a LANDING_REQUEST
+// transaction, and the order is refused if the ledger is full.
+func (g *galaxy_data_t) do_land_command(sp *species_data_t, o *order_t) {
+	if o.get_class_abbr() != SHIP_CLASS {
+		sp.report.order_ignored(o, "Invalid or missing ship.")
+		return
+	}
+	name := o.get_name()
+	ship := sp.find_ship(name)
+	switch {
+	case ship == nil:
+		sp.report.order_ignored(o, fmt.Sprintf("You do not have a ship named %q.", name))
+		return
+	case ship.status == ON_SURFACE:
+		sp.report.order_ignored(o, fmt.Sprintf("%s has already landed.", ship.ship_name()))
+		return
+	case ship.status != IN_ORBIT || ship.pn == 0:
+		sp.report.order_ignored(o, fmt.Sprintf("%s is not in orbit around a planet.", ship.ship_name()))
+		return
+	case ship.class == BA:
+		sp.report.order_ignored(o, "Starbases can't land.")
+		return
+	}
+
+	for _, alien := range g.species {
+		if alien == sp {
+			continue
+		}
+		for _, nampla := range alien.namplas {
+			if !ship.is_at(nampla) || nampla.status&POPULATED == 0 {
+				continue
+			}
+			if g.ledger_full() {
+				sp.report.order_ignored(o, "Too many transactions this turn.")
+				return
+			}
+			granted := alien.is_ally(sp)
+			value := 0
+			if granted {
+				value = 1
+			}
+			g.add_transaction(&trans_data_t{
+				type_:     LANDING_REQUEST,
+				donor:     sp.id,
+				recipient: alien.id,
+				value:     value,
+				x:         nampla.x,
+				y:         nampla.y,
+				z:         nampla.z,
+				pn:        nampla.pn,
+				name1:     ship.ship_name(),
+				name2:     nampla.name,
+			})
+			if !granted {
+				sp.report.order_ignored(o, fmt.Sprintf("SP %s denied permission to land on PL %s.", alien.name, nampla.name))
+				return
+			}
+		}
+	}
+	ship.status = ON_SURFACE
+	sp.report.printf("%s landed.\n", ship.ship_name())
+}
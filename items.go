@@ -0,0 +1,500 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"github.com/playbymail/fhgo/sqlc/sqlite3"
+	"os"
+	"strings"
+)
+
+var (
+	//go:embed catalogs/items.json
+	itemsJSON []byte
+)
+
+// item_data_t describes an item that can be built, carried and transferred.
+type item_data_t struct {
+	item      item_e
+	abbr      string
+	name      string
+	cost      int          // cost in EUs of one unit
+	carry     int          // carrying capacity used by one unit
+	tech      tech_level_e // critical tech needed to build the item
+	min_level int          // minimum level of the critical tech
+}
+
+// defined returns true if the item can be built.
+// The unassigned X slots are not defined unless a game overrides them.
+func (it *item_data_t) defined() bool {
+	return it.cost > 0
+}
+
+// items is the item catalog, indexed by item.
+var items = load_items(itemsJSON)
+
+// load_items parses the item catalog.
+// It panics if the catalog is invalid, since the catalog is embedded in the binary.
+func load_items(data []byte) [MAX_ITEMS]*item_data_t {
+	var input []struct {
+		Abbr     string `json:"abbr"`
+		Name     string `json:"name"`
+		Cost     int    `json:"cost"`
+		Carry    int    `json:"carry"`
+		Tech     string `json:"tech"`
+		MinLevel int    `json:"min_level"`
+	}
+	if err := json.Unmarshal(data, &input); err != nil {
+		panic(fmt.Sprintf("items: %v", err))
+	} else if len(input) != MAX_ITEMS {
+		panic(fmt.Sprintf("items: want %d items, got %d", MAX_ITEMS, len(input)))
+	}
+	var catalog [MAX_ITEMS]*item_data_t
+	for n, it := range input {
+		tech, ok := tech_from_abbr(it.Tech)
+		if !ok {
+			panic(fmt.Sprintf("items: %s: invalid tech %q", it.Abbr, it.Tech))
+		}
+		catalog[n] = &item_data_t{
+			item:      item_e(n),
+			abbr:      it.Abbr,
+			name:      it.Name,
+			cost:      it.Cost,
+			carry:     it.Carry,
+			tech:      tech,
+			min_level: it.MinLevel,
+		}
+	}
+	return catalog
+}
+
+// override_items applies a game's variant rules to the item catalog.
+//
+// The overrides are a JSON list. Each entry names the slot it replaces, using
+// the standard abbreviation, and gives only the fields that change:
+//
+//	[{"slot": "PD", "cost": 2}, {"slot": "X1", "abbr": "MS", "name": "Mine Sweeper", "cost": 300, "carry": 10, "tech": "ML", "min_level": 25}]
+//
+// Only the unassigned X slots may be given a new abbreviation.
+func override_items(catalog *[MAX_ITEMS]*item_data_t, data []byte) error {
+	var input []struct {
+		Slot     string  `json:"slot"`
+		Abbr     *string `json:"abbr"`
+		Name     *string `json:"name"`
+		Cost     *int    `json:"cost"`
+		Carry    *int    `json:"carry"`
+		Tech     *string `json:"tech"`
+		MinLevel *int    `json:"min_level"`
+	}
+	if err := json.Unmarshal(data, &input); err != nil {
+		return err
+	}
+	for _, o := range input {
+		var it *item_data_t
+		for _, slot := range catalog {
+			if strings.EqualFold(slot.abbr, o.Slot) || (slot.item >= X1 && strings.EqualFold(fmt.Sprintf("X%d", slot.item-X1+1), o.Slot)) {
+				it = slot
+				break
+			}
+		}
+		if it == nil {
+			return fmt.Errorf("items: %q: unknown slot", o.Slot)
+		}
+		if o.Abbr != nil {
+			abbr := strings.ToUpper(*o.Abbr)
+			if it.item < X1 {
+				return fmt.Errorf("items: %s: only the X slots may be given a new abbreviation", o.Slot)
+			} else if len(abbr) < 2 || len(abbr) > 3 {
+				return fmt.Errorf("items: %s: abbreviation %q must be two or three letters", o.Slot, abbr)
+			}
+			for _, other := range catalog {
+				if other != it && other.abbr == abbr {
+					return fmt.Errorf("items: %s: abbreviation %q is already used by %s", o.Slot, abbr, other.name)
+				}
+			}
+			if _, ok := tech_from_abbr(abbr); ok || abbr == "PL" || abbr == "SP" || (&order_t{}).get_ship_class_abbr(abbr) {
+				return fmt.Errorf("items: %s: abbreviation %q is reserved", o.Slot, abbr)
+			}
+			it.abbr = abbr
+		}
+		if o.Name != nil {
+			it.name = *o.Name
+		}
+		if o.Cost != nil {
+			if *o.Cost < 0 {
+				return fmt.Errorf("items: %s: cost must not be negative", o.Slot)
+			}
+			it.cost = *o.Cost
+		}
+		if o.Carry != nil {
+			if *o.Carry < 0 {
+				return fmt.Errorf("items: %s: carry must not be negative", o.Slot)
+			}
+			it.carry = *o.Carry
+		}
+		if o.Tech != nil {
+			tech, ok := tech_from_abbr(*o.Tech)
+			if !ok {
+				return fmt.Errorf("items: %s: invalid tech %q", o.Slot, *o.Tech)
+			}
+			it.tech = tech
+		}
+		if o.MinLevel != nil {
+			it.min_level = *o.MinLevel
+		}
+	}
+	return nil
+}
+
+// LoadItems stores the item catalog in the database.
+// If path is not empty, it names a JSON file of overrides for the game.
+func LoadItems(ctx context.Context, q *sqlite3.Queries, path string) error {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := override_items(&items, data); err != nil {
+			return err
+		}
+	}
+	for _, it := range items {
+		err := q.UpsertItem(ctx, sqlite3.UpsertItemParams{
+			ID:       int64(it.item),
+			Abbr:     it.abbr,
+			Name:     it.name,
+			Cost:     int64(it.cost),
+			Carry:    int64(it.carry),
+			Tech:     tech_abbr[it.tech],
+			MinLevel: int64(it.min_level),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// load_item_catalog replaces the item catalog with the one stored in the
+// database, so that the overrides given when the game was created apply to
+// every phase of every turn. The embedded catalog is kept if none is stored.
+func load_item_catalog(ctx context.Context, q *sqlite3.Queries) error {
+	rows, err := q.ListItems(ctx)
+	if err != nil {
+		return err
+	} else if len(rows) == 0 {
+		return nil
+	} else if len(rows) != MAX_ITEMS {
+		return fmt.Errorf("items: want %d items, got %d", MAX_ITEMS, len(rows))
+	}
+	var catalog [MAX_ITEMS]*item_data_t
+	for _, row := range rows {
+		if row.ID < 0 || row.ID >= MAX_ITEMS || catalog[row.ID] != nil {
+			return fmt.Errorf("items: %s: invalid id %d", row.Abbr, row.ID)
+		}
+		tech, ok := tech_from_abbr(row.Tech)
+		if !ok {
+			return fmt.Errorf("items: %s: invalid tech %q", row.Abbr, row.Tech)
+		}
+		catalog[row.ID] = &item_data_t{
+			item:      item_e(row.ID),
+			abbr:      row.Abbr,
+			name:      row.Name,
+			cost:      int(row.Cost),
+			carry:     int(row.Carry),
+			tech:      tech,
+			min_level: int(row.MinLevel),
+		}
+	}
+	items = catalog
+	return nil
+}
+
+func tech_from_abbr(abbr string) (tech_level_e, bool) {
+	for i, tech := range tech_abbr {
+		if strings.EqualFold(abbr, tech) {
+			return tech_level_e(i), true
+		}
+	}
+	return 0, false
+}
+
+func (i item_e) String() string {
+	return items[i].abbr
+}
+
+// item_quantity_name returns the quantity and name of an item, e.g. "10 Colonist Units".
+func item_quantity_name(item item_e, quantity int) string {
+	if quantity == 1 {
+		return fmt.Sprintf("1 %s", items[item].name)
+	}
+	return fmt.Sprintf("%d %ss", quantity, items[item].name)
+}
+
+// get_item_abbr parses an item abbreviation. Returns false if the token is not an item.
+func (o *order_t) get_item_abbr(abbr string) bool {
+	for _, it := range items {
+		if it.defined() && abbr == it.abbr {
+			o.abbr_type, o.abbr_index = ITEM_CLASS, int(it.item)
+			return true
+		}
+	}
+	return false
+}
+
+// cargo_used returns the carrying capacity used by the items on a ship.
+func (s *ship_data_t) cargo_used() int {
+	used := 0
+	for item, quantity := range s.item_quantity {
+		used += quantity * items[item].carry
+	}
+	return used
+}
+
+// cargo_available returns the carrying capacity left on a ship.
+func (s *ship_data_t) cargo_available() int {
+	return s.capacity() - s.cargo_used()
+}
+
+// do_build_item_command executes a BUILD order for items:
+//
+//	BUILD quantity item [ship]
+//
+// The items are stored on the planet or, if a ship at the planet is named, loaded onto the ship.
+func (p *production_t) do_build_item_command(o *order_t, quantity int) {
+	sp := p.species
+	if quantity < 1 {
+		sp.report.order_ignored(o, "The quantity to build must be greater than zero.")
+		return
+	} else if o.get_class_abbr() != ITEM_CLASS {
+		sp.report.order_ignored(o, "Invalid or missing item abbreviation.")
+		return
+	}
+	it := items[o.abbr_index]
+	if sp.tech_level[it.tech] < it.min_level {
+		sp.report.order_ignored(o, fmt.Sprintf("Building %s requires %s %d, but your %s is %d.", it.name, tech_abbr[it.tech], it.min_level, tech_abbr[it.tech], sp.tech_level[it.tech]))
+		return
+	}
+	var ship *ship_data_t
+	if o.remaining() {
+		if o.get_class_abbr() != SHIP_CLASS {
+			sp.report.order_ignored(o, "Invalid ship to receive the items.")
+			return
+		}
+		name := o.get_name()
+		if ship = sp.find_ship(name); ship == nil {
+			sp.report.order_ignored(o, fmt.Sprintf("You do not have a ship named %q.", name))
+			return
+		} else if !ship.is_at(p.nampla) || ship.status == UNDER_CONSTRUCTION {
+			sp.report.order_ignored(o, fmt.Sprintf("%s is not at PL %s.", ship.ship_name(), p.nampla.name))
+			return
+		} else if needed := quantity * it.carry; needed > ship.cargo_available() {
+			sp.report.order_ignored(o, fmt.Sprintf("The order needs %d units of cargo space, but %s has only %d available.", needed, ship.ship_name(), ship.cargo_available()))
+			return
+		}
+	}
+	if it.item == CU && quantity > p.nampla.pop_units {
+		sp.report.order_ignored(o, fmt.Sprintf("PL %s has only %d available population units.", p.nampla.name, p.nampla.pop_units))
+		return
+	}
+	cost := quantity * it.cost
+	if p.check_bounced(cost) {
+		sp.report.order_ignored(o, fmt.Sprintf("Insufficient funds. The order needs %d.", cost))
+		return
+	}
+	if it.item == CU {
+		p.recruit_colonists(quantity, ship)
+	}
+	if ship != nil {
+		ship.item_quantity[it.item] += quantity
+		sp.report.printf("Built %s (%s) at a cost of %d and loaded them onto %s.\n", item_quantity_name(it.item, quantity), it.abbr, cost, ship.ship_name())
+		return
+	}
+	p.nampla.item_quantity[it.item] += quantity
+	sp.report.printf("Built %s (%s) at a cost of %d.\n", item_quantity_name(it.item, quantity), it.abbr, cost)
+}
+
+// is_at returns true if the ship is at the named planet.
+func (s *ship_data_t) is_at(nampla *nampla_data_t) bool {
+	return s.x == nampla.x && s.y == nampla.y && s.z == nampla.z && s.pn == nampla.pn && s.status != IN_DEEP_SPACE
+}
+
+// cargo_holder_t is a named planet or a ship that items can be moved to or from.
+type cargo_holder_t struct {
+	nampla *nampla_data_t
+	ship   *ship_data_t
+}
+
+func (h cargo_holder_t) String() string {
+	if h.nampla != nil {
+		return "PL " + h.nampla.name
+	}
+	return h.ship.ship_name()
+}
+
+func (h cargo_holder_t) item_quantity() *[MAX_ITEMS]int {
+	if h.nampla != nil {
+		return &h.nampla.item_quantity
+	}
+	return &h.ship.item_quantity
+}
+
+// get_cargo_holder parses a named planet or a ship of the species.
+// Returns an error message if the holder is missing or invalid.
+func (sp *species_data_t) get_cargo_holder(o *order_t) (cargo_holder_t, string) {
+	switch o.get_class_abbr() {
+	case PLANET_ID:
+		name := o.get_name()
+		if nampla := sp.find_nampla(name); nampla != nil {
+			return cargo_holder_t{nampla: nampla}, ""
+		}
+		return cargo_holder_t{}, fmt.Sprintf("You do not have a planet named %q.", name)
+	case SHIP_CLASS:
+		name := o.get_name()
+		if ship := sp.find_ship(name); ship == nil {
+			return cargo_holder_t{}, fmt.Sprintf("You do not have a ship named %q.", name)
+		} else if ship.status == UNDER_CONSTRUCTION {
+			return cargo_holder_t{}, fmt.Sprintf("%s is still under construction.", ship.ship_name())
+		} else {
+			return cargo_holder_t{ship: ship}, ""
+		}
+	}
+	return cargo_holder_t{}, "Invalid or missing planet or ship."
+}
+
+// same_location returns true if items can be moved directly between the holders.
+func (h cargo_holder_t) same_location(other cargo_holder_t) bool {
+	switch {
+	case h.nampla != nil && other.nampla != nil:
+		return h.nampla.x == other.nampla.x && h.nampla.y == other.nampla.y && h.nampla.z == other.nampla.z && h.nampla.pn == other.nampla.pn
+	case h.nampla != nil:
+		return other.ship.is_at(h.nampla)
+	case other.nampla != nil:
+		return h.ship.is_at(other.nampla)
+	}
+	return h.ship.x == other.ship.x && h.ship.y == other.ship.y && h.ship.z == other.ship.z
+}
+
+// transfer_items moves items between holders at the same location, checking the cargo capacity of ships.
+// Returns an error message if the transfer is not possible.
+func transfer_items(item item_e, quantity int, from, to cargo_holder_t) string {
+	if !from.same_location(to) {
+		return fmt.Sprintf("%s and %s are not at the same location.", from, to)
+	} else if have := from.item_quantity()[item]; have < quantity {
+		return fmt.Sprintf("%s has only %s.", from, item_quantity_name(item, have))
+	} else if to.ship != nil && quantity*items[item].carry > to.ship.cargo_available() {
+		return fmt.Sprintf("The order needs %d units of cargo space, but %s has only %d available.", quantity*items[item].carry, to, to.ship.cargo_available())
+	}
+	from.item_quantity()[item] -= quantity
+	to.item_quantity()[item] += quantity
+	return ""
+}
+
+// update_colonist_points records colonists moved between a planet and a ship
+// as a load at the ship's loading point or an unload at its unloading point.
+func update_colonist_points(from, to cargo_holder_t) {
+	switch {
+	case from.nampla != nil && to.ship != nil:
+		to.ship.loading_point = colonist_point(from.nampla)
+	case from.ship != nil && to.nampla != nil:
+		from.ship.unloading_point = colonist_point(to.nampla)
+	}
+}
+
+// do_transfer_command executes a TRANSFER order:
+//
+//	TRANSFER quantity item source, destination
+//	TRANSFER quantity item source, SP name, destination
+//
+// A quantity of zero transfers everything at the source. Items may be given
+// to a planet or ship of a species that has been contacted; such transfers
+// are recorded as ITEM_TRANSFER transactions.
+func (g *galaxy_data_t) do_transfer_command(sp *species_data_t, o *order_t) {
+	quantity, ok := o.get_value()
+	if !ok || quantity < 0 {
+		sp.report.order_ignored(o, "Invalid or missing quantity.")
+		return
+	} else if o.get_class_abbr() != ITEM_CLASS {
+		sp.report.order_ignored(o, "Invalid or missing item abbreviation.")
+		return
+	}
+	item := item_e(o.abbr_index)
+	from, reason := sp.get_cargo_holder(o)
+	if reason != "" {
+		sp.report.order_ignored(o, reason)
+		return
+	}
+	var alien *species_data_t
+	if strings.EqualFold(o.peek_token(), "SP") {
+		if alien, reason = g.get_contacted_species(sp, o); reason != "" {
+			sp.report.order_ignored(o, reason)
+			return
+		}
+	}
+	var to cargo_holder_t
+	if alien == nil {
+		to, reason = sp.get_cargo_holder(o)
+	} else {
+		to, reason = alien.get_alien_cargo_holder(o)
+	}
+	if reason != "" {
+		sp.report.order_ignored(o, reason)
+		return
+	}
+	if quantity == 0 {
+		quantity = from.item_quantity()[item]
+	}
+	if reason := transfer_items(item, quantity, from, to); reason != "" {
+		sp.report.order_ignored(o, reason)
+		return
+	}
+	if alien == nil {
+		if item == CU {
+			update_colonist_points(from, to)
+		}
+		sp.report.printf("Transferred %s (%s) from %s to %s.\n", item_quantity_name(item, quantity), items[item].abbr, from, to)
+		return
+	}
+	t := &trans_data_t{
+		type_:     ITEM_TRANSFER,
+		donor:     sp.id,
+		recipient: alien.id,
+		value:     quantity,
+		number1:   int(item),
+		name1:     from.String(),
+		name2:     to.String(),
+	}
+	if from.nampla != nil {
+		t.x, t.y, t.z, t.pn = from.nampla.x, from.nampla.y, from.nampla.z, from.nampla.pn
+	} else {
+		t.x, t.y, t.z, t.pn = from.ship.x, from.ship.y, from.ship.z, from.ship.pn
+	}
+	if !g.add_transaction(t) {
+		transfer_items(item, quantity, to, from)
+		sp.report.order_ignored(o, "Too many transactions this turn.")
+		return
+	}
+	sp.report.printf("Transferred %s (%s) from %s to %s of SP %s.\n", item_quantity_name(item, quantity), items[item].abbr, from, to, alien.name)
+}
+
+// report_inventory adds a list of the items held by a planet or ship to the species' report.
+func (sp *species_data_t) report_inventory(holder cargo_holder_t) {
+	header := false
+	for item, quantity := range holder.item_quantity() {
+		if quantity == 0 {
+			continue
+		}
+		if !header {
+			sp.report.printf("\nInventory of %s:\n", holder)
+			header = true
+		}
+		sp.report.printf("  %-4s %8d  %s\n", items[item].abbr, quantity, items[item].name)
+	}
+	if holder.ship != nil && header {
+		sp.report.printf("  Cargo space used: %d of %d\n", holder.ship.cargo_used(), holder.ship.capacity())
+	}
+}
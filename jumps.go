@@ -0,0 +1,240 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import "fmt"
+
+// do_jump_orders executes the orders in the JUMPS section of a species' orders.
+func (g *galaxy_data_t) do_jump_orders(sp *species_data_t, orders []*order_t) {
+	for _, o := range orders {
+		switch o.command {
+		case JUMP:
+			g.do_jump_command(sp, o)
+		case PJUMP:
+			g.do_pjump_command(sp, o)
+		default:
+			sp.report.order_ignored(o, "Invalid jump command.")
+		}
+	}
+}
+
+// jump_destination_t is where a ship has been ordered to jump to.
+type jump_destination_t struct {
+	x, y, z, pn int
+}
+
+// get_jumping_ship parses the name of a ship that is to jump.
+// Returns nil, after reporting the problem, if the ship can't jump this turn.
+func (sp *species_data_t) get_jumping_ship(o *order_t) *ship_data_t {
+	if o.get_class_abbr() != SHIP_CLASS {
+		sp.report.order_ignored(o, "Invalid or missing ship.")
+		return nil
+	}
+	name := o.get_name()
+	ship := sp.find_ship(name)
+	if ship == nil {
+		sp.report.order_ignored(o, fmt.Sprintf("You do not have a ship named %q.", name))
+		return nil
+	} else if ship.status == UNDER_CONSTRUCTION {
+		sp.report.order_ignored(o, fmt.Sprintf("%s is still under construction.", ship.ship_name()))
+		return nil
+	} else if ship.class == BA {
+		sp.report.order_ignored(o, "Starbases can't jump.")
+		return nil
+	} else if ship.just_jumped {
+		sp.report.order_ignored(o, fmt.Sprintf("%s has already jumped this turn.", ship.ship_name()))
+		return nil
+	}
+	return ship
+}
+
+// get_jump_destination parses the destination of a jump, either coordinates
+// with an optional planet number or the name of one of the species' planets.
+func (g *galaxy_data_t) get_jump_destination(sp *species_data_t, o *order_t) (jump_destination_t, string) {
+	var dest jump_destination_t
+	if o.get_class_abbr() == PLANET_ID {
+		name := o.get_name()
+		nampla := sp.find_nampla(name)
+		if nampla == nil {
+			return dest, fmt.Sprintf("You do not have a planet named %q.", name)
+		}
+		return jump_destination_t{x: nampla.x, y: nampla.y, z: nampla.z, pn: nampla.pn}, ""
+	}
+	for _, p := range []*int{&dest.x, &dest.y, &dest.z} {
+		value, ok := o.get_value()
+		if !ok {
+			return dest, "Invalid or missing destination."
+		}
+		*p = value
+	}
+	if pn, ok := o.get_value(); ok {
+		dest.pn = pn
+	}
+	if dest.pn != 0 {
+		star := g.find_star(dest.x, dest.y, dest.z)
+		if star == nil || dest.pn < 1 || dest.pn > star.num_planets {
+			return dest, fmt.Sprintf("There is no planet #%d at x = %d, y = %d, z = %d.", dest.pn, dest.x, dest.y, dest.z)
+		}
+	}
+	return dest, ""
+}
+
+// mishap_chance returns the chance, in hundredths of a percent, that a jump
+// goes wrong. It grows with the square of the distance and shrinks with the
+// gravitics tech level of the species whose drive or portal is used. Every
+// year of age on the ship or portal makes success two percent less likely.
+func mishap_chance(distance float64, gv, age int) int {
+	if gv < 1 {
+		return 10_000
+	}
+	chance := min(int((100*distance*distance)/float64(gv)), 10_000)
+	success := 10_000 - chance
+	success -= (2 * age * success) / 100
+	return 10_000 - max(success, 0)
+}
+
+// jump moves the ship to the destination, unless the jump goes wrong. Half
+// of all mishaps destroy the ship; the rest leave it stranded in deep space
+// somewhere near the destination.
+func (g *galaxy_data_t) jump(sp *species_data_t, ship *ship_data_t, dest jump_destination_t, chance int) {
+	name := ship.ship_name()
+	ship.just_jumped = true
+	if rnd(10_000) <= chance {
+		if rnd(100) <= 50 {
+			sp.remove_ships([]*ship_data_t{ship})
+			sp.report.printf("%s suffered a mishap while jumping and was destroyed!\n", name)
+			return
+		}
+		ship.x = max(dest.x+rnd(5)-3, 0)
+		ship.y = max(dest.y+rnd(5)-3, 0)
+		ship.z = max(dest.z+rnd(5)-3, 0)
+		ship.pn, ship.status = 0, IN_DEEP_SPACE
+		sp.report.printf("%s suffered a mishap while jumping and is now at x = %d, y = %d, z = %d.\n", name, ship.x, ship.y, ship.z)
+		return
+	}
+	ship.x, ship.y, ship.z, ship.pn = dest.x, dest.y, dest.z, dest.pn
+	ship.status = IN_DEEP_SPACE
+	if dest.pn != 0 {
+		ship.status = IN_ORBIT
+	}
+	sp.report.printf("%s jumped to x = %d, y = %d, z = %d", name, ship.x, ship.y, ship.z)
+	if ship.pn != 0 {
+		sp.report.printf(", planet #%d", ship.pn)
+	}
+	sp.report.printf(".\n")
+}
+
+// do_jump_command executes a JUMP order:
+//
+//	JUMP ship, x y z [pn]
+//	JUMP ship, PL name
+//
+// Only FTL ships can jump on their own. The chance of a mishap depends on the
+// distance, the species' gravitics tech level and the age of the ship.
+func (g *galaxy_data_t) do_jump_command(sp *species_data_t, o *order_t) {
+	ship := sp.get_jumping_ship(o)
+	if ship == nil {
+		return
+	} else if ship.type_ != FTL {
+		sp.report.order_ignored(o, fmt.Sprintf("%s is a sub-light ship and needs a jump portal to jump.", ship.ship_name()))
+		return
+	}
+	dest, reason := g.get_jump_destination(sp, o)
+	if reason != "" {
+		sp.report.order_ignored(o, reason)
+		return
+	}
+	distance := coord_t{x: ship.x, y: ship.y, z: ship.z}.DistanceTo(coord_t{x: dest.x, y: dest.y, z: dest.z})
+	g.jump(sp, ship, dest, mishap_chance(distance, sp.tech_level[GV], ship.age))
+}
+
+// portal_capacity returns the tonnage, in units of 10,000 tons, that a jump
+// portal can send each turn. Every JP unit on the portal adds one unit.
+func (s *ship_data_t) portal_capacity() int {
+	return s.item_quantity[JP]
+}
+
+// find_portal returns the jump portal the species asked for and its owner.
+// The species' own ships are searched first, then the ships of species that
+// consider it an ally. Only portals at the location can be used.
+func (g *galaxy_data_t) find_portal(sp *species_data_t, name string, x, y, z int) (*ship_data_t, *species_data_t) {
+	if portal := sp.find_ship(name); portal != nil && portal.x == x && portal.y == y && portal.z == z {
+		return portal, sp
+	}
+	for _, alien := range g.species {
+		if alien == sp || !alien.is_ally(sp) {
+			continue
+		}
+		if portal := alien.find_ship(name); portal != nil && portal.x == x && portal.y == y && portal.z == z {
+			return portal, alien
+		}
+	}
+	return nil, nil
+}
+
+// do_pjump_command executes a PJUMP order:
+//
+//	PJUMP ship, x y z [pn], portal
+//	PJUMP ship, PL name, portal
+//
+// The ship is sent to the destination by a jump portal at its location: a
+// ship or starbase carrying JP units. Sub-light ships can use portals. A
+// portal can be one of the species' own ships or belong to a species that
+// considers it an ally; the owner of an alien portal is told that it was used
+// through an ALIEN_JUMP_PORTAL_USAGE transaction. The ships sent through a
+// portal in a turn can't weigh more than its portal_capacity. The chance of a
+// mishap depends on the gravitics tech level of the portal's owner and the age of the portal.
+func (g *galaxy_data_t) do_pjump_command(sp *species_data_t, o *order_t) {
+	ship := sp.get_jumping_ship(o)
+	if ship == nil {
+		return
+	}
+	dest, reason := g.get_jump_destination(sp, o)
+	if reason != "" {
+		sp.report.order_ignored(o, reason)
+		return
+	}
+	if o.get_class_abbr() != SHIP_CLASS {
+		sp.report.order_ignored(o, "Invalid or missing jump portal.")
+		return
+	}
+	name := o.get_name()
+	portal, owner := g.find_portal(sp, name, ship.x, ship.y, ship.z)
+	if portal == nil {
+		sp.report.order_ignored(o, fmt.Sprintf("There is no jump portal named %q available to %s.", name, ship.ship_name()))
+		return
+	} else if portal == ship {
+		sp.report.order_ignored(o, "A ship can't use itself as a jump portal.")
+		return
+	} else if portal.status == UNDER_CONSTRUCTION || portal.item_quantity[JP] == 0 {
+		sp.report.order_ignored(o, fmt.Sprintf("%s is not a working jump portal.", portal.ship_name()))
+		return
+	}
+	if g.portal_tonnage == nil {
+		g.portal_tonnage = map[*ship_data_t]int{}
+	}
+	if used := g.portal_tonnage[portal]; used+ship.tonnage > portal.portal_capacity() {
+		sp.report.order_ignored(o, fmt.Sprintf("%s can only send %d tons this turn, and %d tons have already been sent.",
+			portal.ship_name(), portal.portal_capacity()*10_000, used*10_000))
+		return
+	} else if owner != sp && g.ledger_full() {
+		sp.report.order_ignored(o, "Too many transactions this turn.")
+		return
+	}
+	g.portal_tonnage[portal] += ship.tonnage
+
+	if owner != sp {
+		g.add_transaction(&trans_data_t{
+			type_:     ALIEN_JUMP_PORTAL_USAGE,
+			donor:     sp.id,
+			recipient: owner.id,
+			x:         ship.x,
+			y:         ship.y,
+			z:         ship.z,
+			name1:     ship.ship_name(),
+			name2:     portal.ship_name(),
+		})
+	}
+	distance := coord_t{x: ship.x, y: ship.y, z: ship.z}.DistanceTo(coord_t{x: dest.x, y: dest.y, z: dest.z})
+	g.jump(sp, ship, dest, mishap_chance(distance, owner.tech_level[GV], portal.age))
+}
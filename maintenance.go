@@ -0,0 +1,101 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+// maintenance_cost returns the cost in EUs of keeping the ship in service for
+// a turn, before the species' ML discount. A ship costs one twenty-fifth of
+// the price of an FTL ship of its class and tonnage. Starbases cost a quarter
+// of that and sub-light ships three quarters. Ships under construction cost nothing.
+func (s *ship_data_t) maintenance_cost() int {
+	if s.status == UNDER_CONSTRUCTION {
+		return 0
+	}
+	cost := ship_classes[s.class].cost_of(s.tonnage, false) / 25
+	switch {
+	case s.class == BA:
+		cost /= 4
+	case s.type_ == SUB_LIGHT:
+		cost -= cost / 4
+	}
+	return cost
+}
+
+// fleet_maintenance returns the cost in EUs of maintaining the species' fleet
+// for a turn. Every level of military tech takes one percent off the cost, up
+// to half of it.
+func (sp *species_data_t) fleet_maintenance() int {
+	cost := 0
+	for _, ship := range sp.ships {
+		cost += ship.maintenance_cost()
+	}
+	cost -= (cost * min(sp.tech_level[ML], 50)) / 100
+	return cost
+}
+
+// total_production returns the EUs the species' colonies produce in a turn.
+// Mining and resort colonies count the two thirds that go to the treasury;
+// other colonies count what they can spend, which is limited by their raw
+// materials and their manufacturing capacity.
+func (sp *species_data_t) total_production() int {
+	total := 0
+	for _, nampla := range sp.namplas {
+		if nampla.planet == nil || nampla.status&POPULATED == 0 || nampla.status&DISBANDED_COLONY != 0 {
+			continue
+		}
+		raw_material_units, production_capacity := sp.planet_output(nampla)
+		switch {
+		case nampla.status&MINING_COLONY != 0:
+			total += (2 * raw_material_units) / 3
+		case nampla.status&RESORT_COLONY != 0:
+			total += (2 * production_capacity) / 3
+		default:
+			total += min(raw_material_units+nampla.item_quantity[RM], production_capacity)
+		}
+	}
+	return total
+}
+
+// update_fleet_cost computes the fleet maintenance cost and its share of the species' production.
+func (sp *species_data_t) update_fleet_cost() {
+	sp.fleet_cost = sp.fleet_maintenance()
+	sp.fleet_percent_cost = 0
+	if total := sp.total_production(); total > 0 {
+		sp.fleet_percent_cost = (10_000 * sp.fleet_cost) / total
+	} else if sp.fleet_cost > 0 {
+		sp.fleet_percent_cost = 10_000
+	}
+}
+
+// pay_fleet_maintenance charges the species' treasury for the upkeep of its
+// fleet at the end of the turn. If the treasury can't cover the cost, it is
+// emptied and every ship in service ages an extra year from neglect. Ships
+// that are aged past 49 this way fall apart and are lost.
+func (sp *species_data_t) pay_fleet_maintenance() {
+	sp.update_fleet_cost()
+	if sp.fleet_cost == 0 {
+		return
+	}
+	sp.report.printf("\nFleet maintenance cost is %d (%d.%02d%% of total production).\n",
+		sp.fleet_cost, sp.fleet_percent_cost/100, sp.fleet_percent_cost%100)
+	if sp.fleet_cost <= sp.econ_units {
+		sp.econ_units -= sp.fleet_cost
+		return
+	}
+	shortfall := sp.fleet_cost - sp.econ_units
+	sp.econ_units = 0
+	sp.report.printf("Your treasury was %d short of the maintenance cost. Your ships were neglected and aged an extra year:\n", shortfall)
+	var lost []*ship_data_t
+	for _, ship := range sp.ships {
+		if ship.status == UNDER_CONSTRUCTION {
+			continue
+		}
+		ship.age++
+		if ship.age > 49 {
+			sp.report.printf("  %s, age %d, fell apart and was lost\n", ship.ship_name(), ship.age)
+			lost = append(lost, ship)
+			continue
+		}
+		sp.report.printf("  %s, age %d\n", ship.ship_name(), ship.age)
+	}
+	sp.remove_ships(lost)
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"context"
+	"github.com/playbymail/fhgo/sqlc/sqlite3"
+	"strings"
+)
+
+// message_data_t is a message sent from one species to another.
+type message_data_t struct {
+	sender    species_id_t
+	recipient species_id_t
+	text      string
+}
+
+// do_message_command executes a MESSAGE order:
+//
+//	MESSAGE SP name
+//	text of the message
+//	ZZZ
+//
+// The message is delivered at the end of the turn and is included in the
+// recipient's report. Messages can only be sent to species that have been met.
+func (g *galaxy_data_t) do_message_command(sp *species_data_t, o *order_t) {
+	if !o.message_ended {
+		sp.report.order_ignored(o, "The message must be ended by a ZZZ line.")
+		return
+	}
+	alien, reason := g.get_contacted_species(sp, o)
+	if reason != "" {
+		sp.report.order_ignored(o, reason)
+		return
+	}
+	t := &trans_data_t{type_: MESSAGE_TO_SPECIES, donor: sp.id, recipient: alien.id, value: len(o.message)}
+	if !g.add_transaction(t) {
+		sp.report.order_ignored(o, "Too many transactions this turn.")
+		return
+	}
+	g.messages = append(g.messages, &message_data_t{
+		sender:    sp.id,
+		recipient: alien.id,
+		text:      strings.Join(o.message, "\n"),
+	})
+	sp.report.printf("A message will be sent to SP %s.\n", alien.name)
+}
+
+// report_messages adds the messages sent to the species during the turn to its report.
+func (g *galaxy_data_t) report_messages(sp *species_data_t) {
+	for _, m := range g.messages {
+		if m.recipient != sp.id {
+			continue
+		}
+		sp.report.printf("\nMessage from SP %s:\n", g.species_name(m.sender))
+		for _, line := range strings.Split(m.text, "\n") {
+			if line == "" {
+				sp.report.printf("\n")
+				continue
+			}
+			sp.report.printf("  %s\n", line)
+		}
+	}
+}
+
+// save_messages replaces the messages sent during the turn.
+func (g *galaxy_data_t) save_messages(ctx context.Context, q *sqlite3.Queries) error {
+	if err := q.DeleteMessages(ctx, int64(g.turn_number)); err != nil {
+		return err
+	}
+	for _, m := range g.messages {
+		err := q.CreateMessage(ctx, sqlite3.CreateMessageParams{
+			TurnNumber:  int64(g.turn_number),
+			SenderID:    int64(m.sender),
+			RecipientID: int64(m.recipient),
+			Message:     m.text,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// load_messages reads the messages sent during the turn, so that phases run
+// separately can add to the messages of earlier phases.
+func (g *galaxy_data_t) load_messages(ctx context.Context, q *sqlite3.Queries) error {
+	rows, err := q.ListMessages(ctx, int64(g.turn_number))
+	if err != nil {
+		return err
+	}
+	g.messages = nil
+	for _, row := range rows {
+		g.messages = append(g.messages, &message_data_t{
+			sender:    species_id_t(row.SenderID),
+			recipient: species_id_t(row.RecipientID),
+			text:      row.Message,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+// is_disguised returns true if the ship carries enough FD units to mask its
+// identity. The field distortion needs one FD unit for every unit of tonnage.
+func (s *ship_data_t) is_disguised() bool {
+	return s.tonnage > 0 && s.item_quantity[FD] >= s.tonnage
+}
+
+// disguised_name returns the name other species see for a disguised ship.
+// Only the class of the ship can be made out.
+func (s *ship_data_t) disguised_name() string {
+	return ship_classes[s.class].abbr + " ???"
+}
+
+// observable is something whose description depends on which species is looking at it.
+// Text shared by several species, such as a battle log, is rendered separately for each one.
+type observable interface {
+	observed_by(observer *species_data_t) string
+}
+
+// ship_view_t is a ship and its owner, as described to an observer.
+type ship_view_t struct {
+	ship  *ship_data_t
+	owner *species_data_t
+}
+
+// observed_by returns the ship's name and owner. Other species see a disguised
+// ship as an unknown ship of an unknown species.
+func (v ship_view_t) observed_by(observer *species_data_t) string {
+	if observer == v.owner || !v.ship.is_disguised() {
+		return v.ship.ship_name() + " of SP " + v.owner.name
+	}
+	return v.ship.disguised_name() + " of SP ???"
+}
+
+func (v ship_view_t) String() string {
+	return v.observed_by(v.owner)
+}
+
+// plain_view_t is a description that is the same for every observer.
+type plain_view_t string
+
+func (v plain_view_t) observed_by(observer *species_data_t) string {
+	return string(v)
+}
+
+func (v plain_view_t) String() string {
+	return string(v)
+}
+
+// observe replaces the observable arguments of a log line with their descriptions for the observer.
+func observe(observer *species_data_t, args []any) []any {
+	viewed := make([]any, len(args))
+	for n, arg := range args {
+		if v, ok := arg.(observable); ok {
+			viewed[n] = v.observed_by(observer)
+		} else {
+			viewed[n] = arg
+		}
+	}
+	return viewed
+}
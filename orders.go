@@ -0,0 +1,234 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Command names, indexed by command code.
+// The parser only looks at the first three letters of a command.
+var command_name = [NUM_COMMANDS]string{
+	"Undefined", "Ally", "Ambush", "Attack", "Auto", "Base", "Battle", "Build", "Continue", "Deep",
+	"Destroy", "Develop", "Disband", "End", "Enemy", "Engage", "Estimate", "Haven", "Hide", "Hijack",
+	"Ibuild", "Icontinue", "Install", "Intercept", "Jump", "Land", "Message", "Move", "Name", "Neutral",
+	"Orbit", "Pjump", "Production", "Recycle", "Repair", "Research", "Scan", "Send", "Shipyard", "Start",
+	"Summary", "Surrender", "Target", "Teach", "Tech", "Telescope", "Terraform", "Transfer", "Unload", "Upgrade",
+	"Visited", "Withdraw", "Wormhole", "ZZZ",
+}
+
+func (c command_code_e) String() string {
+	if c < 0 || c >= NUM_COMMANDS {
+		return command_name[UNDEFINED]
+	}
+	return command_name[c]
+}
+
+// Sections of an orders file. Each section starts with a "START <section>" line
+// and ends with an "END" line.
+type order_section_e int
+
+const (
+	NO_SECTION order_section_e = iota
+	COMBAT_SECTION
+	PRE_DEPARTURE_SECTION
+	JUMP_SECTION
+	PRODUCTION_SECTION
+	POST_ARRIVAL_SECTION
+	STRIKE_SECTION
+)
+
+var order_section_name = map[string]order_section_e{
+	"COMBAT":        COMBAT_SECTION,
+	"PRE-DEPARTURE": PRE_DEPARTURE_SECTION,
+	"JUMPS":         JUMP_SECTION,
+	"PRODUCTION":    PRODUCTION_SECTION,
+	"POST-ARRIVAL":  POST_ARRIVAL_SECTION,
+	"STRIKES":       STRIKE_SECTION,
+}
+
+// String returns the name of the section, as it appears in orders files.
+func (s order_section_e) String() string {
+	for name, section := range order_section_name {
+		if section == s {
+			return name
+		}
+	}
+	return "NONE"
+}
+
+// order_t is a single line from a species' orders.
+//
+// The get_* methods consume the unparsed remainder of the line in the same
+// way that the original parser consumed its input buffer. The results of
+// get_class_abbr are left in the abbr_* fields.
+type order_t struct {
+	line    int            // line number in the orders file
+	text    string         // original text of the order, without comments
+	command command_code_e // command code, UNDEFINED if the command was not recognized
+	input   string         // unparsed remainder of the order
+
+	abbr_type  parser_token_e // type of the last abbreviation parsed
+	abbr_index int            // index of the last abbreviation parsed
+	sub_light  bool           // set if the last ship class parsed was sub-light
+	tonnage    int            // tonnage of the last ship class parsed, if it was given
+
+	message       []string // text of a MESSAGE order, one entry per line
+	message_ended bool     // set if the text of a MESSAGE order was ended by a ZZZ line
+}
+
+// orders_t is the set of orders submitted by a species for a single turn, grouped by section.
+type orders_t map[order_section_e][]*order_t
+
+// parse_orders reads an orders file and returns the orders grouped by section.
+// Comments start with a semicolon and run to the end of the line.
+// Lines outside a section are ignored, as are blank lines.
+//
+// The lines following a MESSAGE order, up to a line starting with ZZZ, are
+// the text of the message. They are kept as written, comments included.
+func parse_orders(r io.Reader) (orders_t, error) {
+	orders := orders_t{}
+	section := NO_SECTION
+	var message *order_t
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if message != nil {
+			if new_order(line, strings.TrimSpace(text)).command == ZZZ {
+				message.message_ended = true
+				message = nil
+			} else {
+				message.message = append(message.message, strings.TrimRight(text, " \t"))
+			}
+			continue
+		}
+		if n := strings.IndexByte(text, ';'); n != -1 {
+			text = text[:n]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		o := new_order(line, text)
+		switch o.command {
+		case START:
+			section = order_section_name[strings.ToUpper(strings.TrimSpace(o.input))]
+			continue
+		case END:
+			section = NO_SECTION
+			continue
+		case MESSAGE:
+			message = o
+		}
+		if section == NO_SECTION {
+			continue
+		}
+		orders[section] = append(orders[section], o)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// new_order returns an order with the command parsed from the text.
+func new_order(line int, text string) *order_t {
+	o := &order_t{line: line, text: text}
+	word, rest := text, ""
+	if n := strings.IndexFunc(text, unicode.IsSpace); n != -1 {
+		word, rest = text[:n], text[n:]
+	}
+	o.input = strings.TrimSpace(rest)
+	if len(word) < 3 {
+		return o
+	}
+	abbr := strings.ToUpper(word[:3])
+	for c := ALLY; c < NUM_COMMANDS; c++ {
+		if strings.ToUpper(command_name[c][:3]) == abbr {
+			o.command = c
+			break
+		}
+	}
+	return o
+}
+
+// next_token removes and returns the next word of the unparsed input.
+// Commas are treated as separators.
+func (o *order_t) next_token() string {
+	o.input = strings.TrimLeft(o.input, " \t,")
+	n := strings.IndexAny(o.input, " \t,")
+	if n == -1 {
+		n = len(o.input)
+	}
+	token := o.input[:n]
+	o.input = o.input[n:]
+	return token
+}
+
+// peek_token returns the next word of the unparsed input without consuming it.
+func (o *order_t) peek_token() string {
+	saved := o.input
+	token := o.next_token()
+	o.input = saved
+	return token
+}
+
+// get_value parses an integer value from the input.
+// Returns false, and leaves the input untouched, if the next token is not an integer.
+func (o *order_t) get_value() (int, bool) {
+	value, err := strconv.Atoi(o.peek_token())
+	if err != nil {
+		return 0, false
+	}
+	o.next_token()
+	return value, true
+}
+
+// get_name parses a name from the input. The name runs up to the next comma or the end of the line.
+func (o *order_t) get_name() string {
+	o.input = strings.TrimLeft(o.input, " \t,")
+	n := strings.IndexByte(o.input, ',')
+	if n == -1 {
+		n = len(o.input)
+	}
+	name := strings.Join(strings.Fields(o.input[:n]), " ")
+	o.input = o.input[n:]
+	return name
+}
+
+// get_class_abbr parses an abbreviation from the input and returns its type.
+// The index of the abbreviation is saved in abbr_index.
+// Returns UNKNOWN, and leaves the input untouched, if the abbreviation is not recognized.
+func (o *order_t) get_class_abbr() parser_token_e {
+	o.abbr_type, o.abbr_index, o.sub_light, o.tonnage = UNKNOWN, 0, false, 0
+	abbr := strings.ToUpper(o.peek_token())
+	switch abbr {
+	case "PL":
+		o.abbr_type = PLANET_ID
+	case "SP":
+		o.abbr_type = SPECIES_ID
+	default:
+		for i, tech := range tech_abbr {
+			if abbr == tech {
+				o.abbr_type, o.abbr_index = TECH_ID, i
+				break
+			}
+		}
+		if o.abbr_type == UNKNOWN && !o.get_item_abbr(abbr) {
+			o.get_ship_class_abbr(abbr)
+		}
+	}
+	if o.abbr_type != UNKNOWN {
+		o.next_token()
+	}
+	return o.abbr_type
+}
+
+// remaining returns true if there is unparsed input left on the line.
+func (o *order_t) remaining() bool {
+	return strings.Trim(o.input, " \t,") != ""
+}
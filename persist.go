@@ -0,0 +1,584 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/playbymail/fhgo/prng"
+	"github.com/playbymail/fhgo/sqlc/sqlite3"
+)
+
+// load_galaxy reads the galaxy, its species and everything they own, along
+// with the game's item catalog and the turn's ledger and messages, and
+// restores the random number generator to the state saved with the galaxy.
+func load_galaxy(ctx context.Context, q *sqlite3.Queries) (*galaxy_data_t, error) {
+	row, err := q.GetGalaxy(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("galaxy: %w", err)
+	}
+	g := &galaxy_data_t{
+		d_num_species: int(row.NumSpecies),
+		num_species:   int(row.NumSpecies),
+		radius:        int(row.Radius),
+		turn_number:   int(row.TurnNumber),
+	}
+	prng.SetSeed(uint64(row.PrngSeed))
+
+	phases, err := q.ListTurnPhases(ctx, row.TurnNumber)
+	if err != nil {
+		return nil, err
+	}
+	g.phases = map[string]bool{}
+	for _, phase := range phases {
+		g.phases[phase] = true
+	}
+
+	if err := load_item_catalog(ctx, q); err != nil {
+		return nil, err
+	}
+	if err := g.load_stars(ctx, q); err != nil {
+		return nil, err
+	}
+	planets := map[planet_id_t]*planet_data_t{}
+	for _, star := range g.stars {
+		for _, planet := range star.planets {
+			if planet != nil {
+				planets[planet.id] = planet
+			}
+		}
+	}
+	if err := g.load_star_visits(ctx, q); err != nil {
+		return nil, err
+	}
+	if err := g.load_planet_history(ctx, q, planets); err != nil {
+		return nil, err
+	}
+	if err := g.load_species(ctx, q, planets); err != nil {
+		return nil, err
+	}
+	if err := g.load_transactions(ctx, q); err != nil {
+		return nil, err
+	}
+	if err := g.load_messages(ctx, q); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// load_star_visits reads the star systems each species has visited.
+func (g *galaxy_data_t) load_star_visits(ctx context.Context, q *sqlite3.Queries) error {
+	rows, err := q.ListStarVisits(ctx)
+	if err != nil {
+		return err
+	}
+	stars := map[star_id_t]*star_data_t{}
+	for _, star := range g.stars {
+		stars[star.id] = star
+	}
+	for _, row := range rows {
+		star, ok := stars[star_id_t(row.StarID)]
+		if !ok {
+			continue
+		}
+		if star.visited_by == nil {
+			star.visited_by = map[species_id_t]bool{}
+		}
+		star.visited_by[species_id_t(row.SpeciesID)] = true
+	}
+	return nil
+}
+
+// load_planet_history reads the changes made to planets earlier in the turn.
+func (g *galaxy_data_t) load_planet_history(ctx context.Context, q *sqlite3.Queries, planets map[planet_id_t]*planet_data_t) error {
+	rows, err := q.ListPlanetHistoryForTurn(ctx, int64(g.turn_number))
+	if err != nil {
+		return err
+	}
+	g.planet_history = nil
+	for _, row := range rows {
+		planet, ok := planets[planet_id_t(row.PlanetID)]
+		if !ok {
+			return fmt.Errorf("planet history: invalid planet %d", row.PlanetID)
+		}
+		g.planet_history = append(g.planet_history, &planet_history_t{
+			turn_number: int(row.TurnNumber),
+			planet:      planet,
+			species:     species_id_t(row.SpeciesID),
+			attribute:   row.Attribute,
+			old_value:   row.OldValue,
+			new_value:   row.NewValue,
+			reason:      row.Reason,
+		})
+	}
+	return nil
+}
+
+// load_species reads every species along with its home planet, gases, tech
+// levels, relations, named planets, ships and the report written earlier in the turn.
+func (g *galaxy_data_t) load_species(ctx context.Context, q *sqlite3.Queries, planets map[planet_id_t]*planet_data_t) error {
+	rows, err := q.ListSpecies(ctx)
+	if err != nil {
+		return err
+	}
+	g.species = nil
+	species := map[species_id_t]*species_data_t{}
+	for _, row := range rows {
+		sp := &species_data_t{
+			id:                 species_id_t(row.ID),
+			index:              len(g.species),
+			name:               row.Name,
+			govt_name:          row.GovtName,
+			govt_type:          row.GovtType,
+			auto_orders:        row.AutoOrders != 0,
+			econ_units:         int(row.EconUnits),
+			fleet_cost:         int(row.FleetCost),
+			fleet_percent_cost: int(row.FleetPercentCost),
+			contact:            map[species_id_t]bool{},
+			ally:               map[species_id_t]bool{},
+			enemy:              map[species_id_t]bool{},
+		}
+		report, err := q.GetSpeciesReport(ctx, sqlite3.GetSpeciesReportParams{TurnNumber: int64(g.turn_number), SpeciesID: row.ID})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		sp.report.sb.WriteString(report)
+		g.species = append(g.species, sp)
+		species[sp.id] = sp
+	}
+	g.num_species = len(g.species)
+
+	hrows, err := q.ListSpeciesHomePlanets(ctx)
+	if err != nil {
+		return err
+	}
+	for _, row := range hrows {
+		sp, ok := species[species_id_t(row.SpeciesID)]
+		if !ok {
+			continue
+		}
+		planet, ok := planets[planet_id_t(row.PlanetID)]
+		if !ok {
+			return fmt.Errorf("species %d: invalid home planet %d", row.SpeciesID, row.PlanetID)
+		}
+		sp.home.star, sp.home.planet = planet.star, planet
+		sp.x, sp.y, sp.z, sp.pn = planet.star.x, planet.star.y, planet.star.z, planet.orbit
+		sp.hp_original_base = int(row.HpOriginalBase)
+	}
+
+	grows, err := q.ListSpeciesAtmosphericGases(ctx)
+	if err != nil {
+		return err
+	}
+	neutral, poison := map[*species_data_t]int{}, map[*species_data_t]int{}
+	for _, row := range grows {
+		sp, ok := species[species_id_t(row.SpeciesID)]
+		if !ok {
+			continue
+		}
+		gas := gas_e(row.GasID)
+		switch {
+		case row.Required != 0:
+			sp.required_gas, sp.required_gas_min, sp.required_gas_max = gas, int(row.MinPercentage), int(row.MaxPercentage)
+		case row.Poison != 0:
+			if poison[sp] < len(sp.poison_gas) {
+				sp.poison_gas[poison[sp]] = gas
+				poison[sp]++
+			}
+		default:
+			if neutral[sp] < len(sp.neutral_gas) {
+				sp.neutral_gas[neutral[sp]] = gas
+				neutral[sp]++
+			}
+		}
+	}
+
+	trows, err := q.ListSpeciesTechLevels(ctx)
+	if err != nil {
+		return err
+	}
+	for _, row := range trows {
+		sp, ok := species[species_id_t(row.SpeciesID)]
+		if !ok {
+			continue
+		}
+		sp.tech_level = [6]int{int(row.Mi), int(row.Ma), int(row.Ml), int(row.Gv), int(row.Ls), int(row.Bi)}
+		sp.tech_eps = [6]int{int(row.MiExp), int(row.MaExp), int(row.MlExp), int(row.GvExp), int(row.LsExp), int(row.BiExp)}
+		sp.tech_knowledge = [6]int{int(row.MiUnapplied), int(row.MaUnapplied), int(row.MlUnapplied), int(row.GvUnapplied), int(row.LsUnapplied), int(row.BiUnapplied)}
+		sp.init_tech_level = sp.tech_level
+	}
+	for _, sp := range g.species {
+		if err := sp.load_tech_audit(ctx, q, g.turn_number); err != nil {
+			return err
+		}
+	}
+
+	for _, sp := range g.species {
+		if err := sp.load_relations(ctx, q); err != nil {
+			return err
+		}
+	}
+
+	if err := g.load_namplas(ctx, q, species, planets); err != nil {
+		return err
+	}
+	return g.load_ships(ctx, q, species)
+}
+
+// load_tech_audit reads the changes made to the species' tech levels earlier in the turn.
+func (sp *species_data_t) load_tech_audit(ctx context.Context, q *sqlite3.Queries, turn_number int) error {
+	rows, err := q.ListSpeciesTechAudit(ctx, sqlite3.ListSpeciesTechAuditParams{SpeciesID: int64(sp.id), TurnNumber: int64(turn_number)})
+	if err != nil {
+		return err
+	}
+	sp.tech_audit = nil
+	for _, row := range rows {
+		tech, ok := tech_from_abbr(row.Tech)
+		if !ok {
+			return fmt.Errorf("species %d: tech audit: invalid tech %q", sp.id, row.Tech)
+		}
+		sp.tech_audit = append(sp.tech_audit, &tech_audit_t{
+			turn_number: int(row.TurnNumber),
+			tech:        tech,
+			old_level:   int(row.OldLevel),
+			new_level:   int(row.NewLevel),
+			cost:        int(row.Cost),
+			reason:      tech_audit_reason_from_name(row.Reason),
+		})
+	}
+	return nil
+}
+
+// load_namplas reads the named planets of every species and the items stored on them.
+func (g *galaxy_data_t) load_namplas(ctx context.Context, q *sqlite3.Queries, species map[species_id_t]*species_data_t, planets map[planet_id_t]*planet_data_t) error {
+	rows, err := q.ListNamplas(ctx)
+	if err != nil {
+		return err
+	}
+	type nampla_key_t struct {
+		species species_id_t
+		id      nampla_id_t
+	}
+	namplas := map[nampla_key_t]*nampla_data_t{}
+	for _, row := range rows {
+		sp, ok := species[species_id_t(row.SpeciesID)]
+		if !ok {
+			return fmt.Errorf("nampla %d: invalid species %d", row.ID, row.SpeciesID)
+		}
+		planet, ok := planets[planet_id_t(row.PlanetID)]
+		if !ok {
+			return fmt.Errorf("nampla %d: invalid planet %d", row.ID, row.PlanetID)
+		}
+		nampla := &nampla_data_t{
+			id:             nampla_id_t(row.ID),
+			name:           row.Name,
+			x:              planet.star.x,
+			y:              planet.star.y,
+			z:              planet.star.z,
+			pn:             planet.orbit,
+			status:         planet_status_e(row.Status),
+			hiding:         row.Hiding != 0,
+			hidden:         row.Hidden != 0,
+			siege_eff:      int(row.SiegeEff),
+			shipyards:      int(row.Shipyards),
+			IUs_needed:     int(row.IUsNeeded),
+			AUs_needed:     int(row.AUsNeeded),
+			auto_IUs:       int(row.AutoIUs),
+			auto_AUs:       int(row.AutoAUs),
+			IUs_to_install: int(row.IUsToInstall),
+			AUs_to_install: int(row.AUsToInstall),
+			mi_base:        int(row.MiBase),
+			ma_base:        int(row.MaBase),
+			pop_units:      int(row.PopUnits),
+			recruited:      int(row.Recruited),
+			use_on_ambush:  int(row.UseOnAmbush),
+			message:        message_id_t(row.Message),
+			special:        int(row.Special),
+			star:           planet.star,
+			planet:         planet,
+		}
+		sp.namplas = append(sp.namplas, nampla)
+		sp.num_namplas = len(sp.namplas)
+		if nampla.status&HOME_PLANET != 0 {
+			sp.home.nampla = nampla
+		}
+		namplas[nampla_key_t{sp.id, nampla.id}] = nampla
+	}
+
+	irows, err := q.ListNamplaInventory(ctx)
+	if err != nil {
+		return err
+	}
+	for _, row := range irows {
+		nampla, ok := namplas[nampla_key_t{species_id_t(row.SpeciesID), nampla_id_t(row.NamplaID)}]
+		if !ok || row.ItemID < 0 || row.ItemID >= MAX_ITEMS {
+			return fmt.Errorf("nampla %d: invalid inventory item %d", row.NamplaID, row.ItemID)
+		}
+		nampla.item_quantity[row.ItemID] = int(row.Quantity)
+	}
+	return nil
+}
+
+// load_ships reads the ships of every species and the items they carry.
+func (g *galaxy_data_t) load_ships(ctx context.Context, q *sqlite3.Queries, species map[species_id_t]*species_data_t) error {
+	rows, err := q.ListShips(ctx)
+	if err != nil {
+		return err
+	}
+	ships := map[ship_id_t]*ship_data_t{}
+	for _, row := range rows {
+		sp, ok := species[species_id_t(row.SpeciesID)]
+		if !ok {
+			return fmt.Errorf("ship %d: invalid species %d", row.ID, row.SpeciesID)
+		} else if row.Class < 0 || row.Class >= int64(NUM_SHIP_CLASSES) {
+			return fmt.Errorf("ship %d: invalid class %d", row.ID, row.Class)
+		}
+		ship := &ship_data_t{
+			id:                   ship_id_t(row.ID),
+			name:                 row.Name,
+			x:                    int(row.X),
+			y:                    int(row.Y),
+			z:                    int(row.Z),
+			pn:                   int(row.Pn),
+			status:               ship_status_e(row.Status),
+			type_:                ship_e(row.Type),
+			dest_x:               int(row.DestX),
+			dest_y:               int(row.DestY),
+			dest_z:               int(row.DestZ),
+			just_jumped:          row.JustJumped != 0,
+			arrived_via_wormhole: row.ArrivedViaWormhole != 0,
+			class:                ship_class_e(row.Class),
+			tonnage:              int(row.Tonnage),
+			age:                  int(row.Age),
+			damage:               int(row.Damage),
+			remaining_cost:       int(row.RemainingCost),
+			loading_point:        nampla_id_t(row.LoadingPoint),
+			unloading_point:      nampla_id_t(row.UnloadingPoint),
+			special:              int(row.Special),
+		}
+		sp.ships = append(sp.ships, ship)
+		sp.num_ships = len(sp.ships)
+		ships[ship.id] = ship
+	}
+
+	irows, err := q.ListShipInventory(ctx)
+	if err != nil {
+		return err
+	}
+	for _, row := range irows {
+		ship, ok := ships[ship_id_t(row.ShipID)]
+		if !ok || row.ItemID < 0 || row.ItemID >= MAX_ITEMS {
+			return fmt.Errorf("ship %d: invalid inventory item %d", row.ShipID, row.ItemID)
+		}
+		ship.item_quantity[row.ItemID] = int(row.Quantity)
+	}
+	return nil
+}
+
+// save_galaxy stores the turn number and the state of the random number generator.
+func (g *galaxy_data_t) save_galaxy(ctx context.Context, q *sqlite3.Queries) error {
+	return q.UpdateGalaxy(ctx, sqlite3.UpdateGalaxyParams{
+		TurnNumber: int64(g.turn_number),
+		PrngSeed:   int64(prng.Seed()),
+	})
+}
+
+// save_phase records that the phase being run has been run for the turn.
+func (g *galaxy_data_t) save_phase(ctx context.Context, q *sqlite3.Queries) error {
+	if g.phase == "" {
+		return nil
+	}
+	return q.CreateTurnPhase(ctx, sqlite3.CreateTurnPhaseParams{
+		TurnNumber: int64(g.turn_number),
+		Phase:      g.phase,
+	})
+}
+
+// save_species stores every species, replacing their named planets and ships,
+// and records the star systems they have visited and their reports.
+func (g *galaxy_data_t) save_species(ctx context.Context, q *sqlite3.Queries) error {
+	// ships can change hands, so every species' ships are removed before any are stored
+	for _, sp := range g.species {
+		if err := q.DeleteSpeciesShipInventory(ctx, int64(sp.id)); err != nil {
+			return err
+		} else if err := q.DeleteSpeciesShips(ctx, int64(sp.id)); err != nil {
+			return err
+		}
+	}
+	g.assign_ship_ids()
+
+	for _, sp := range g.species {
+		err := q.UpdateSpecies(ctx, sqlite3.UpdateSpeciesParams{
+			AutoOrders:       boolToInt64(sp.auto_orders),
+			EconUnits:        int64(sp.econ_units),
+			FleetCost:        int64(sp.fleet_cost),
+			FleetPercentCost: int64(sp.fleet_percent_cost),
+			ID:               int64(sp.id),
+		})
+		if err != nil {
+			return err
+		}
+		err = q.UpdateSpeciesHomePlanet(ctx, sqlite3.UpdateSpeciesHomePlanetParams{
+			HpOriginalBase: int64(sp.hp_original_base),
+			SpeciesID:      int64(sp.id),
+		})
+		if err != nil {
+			return err
+		}
+		if err := sp.save_namplas(ctx, q); err != nil {
+			return err
+		} else if err := sp.save_ships(ctx, q); err != nil {
+			return err
+		}
+		err = q.UpsertSpeciesReport(ctx, sqlite3.UpsertSpeciesReportParams{
+			TurnNumber: int64(g.turn_number),
+			SpeciesID:  int64(sp.id),
+			Report:     sp.report.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, star := range g.stars {
+		for _, sp := range g.species {
+			if !star.visited_by[sp.id] {
+				continue
+			}
+			err := q.CreateStarVisit(ctx, sqlite3.CreateStarVisitParams{
+				StarID:     int64(star.id),
+				SpeciesID:  int64(sp.id),
+				TurnNumber: int64(g.turn_number),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// assign_ship_ids gives an identifier to every ship built during the turn.
+func (g *galaxy_data_t) assign_ship_ids() {
+	var last ship_id_t
+	for _, sp := range g.species {
+		for _, ship := range sp.ships {
+			last = max(last, ship.id)
+		}
+	}
+	for _, sp := range g.species {
+		for _, ship := range sp.ships {
+			if ship.id == 0 {
+				last++
+				ship.id = last
+			}
+		}
+	}
+}
+
+// save_namplas replaces the species' named planets and the items stored on them.
+func (sp *species_data_t) save_namplas(ctx context.Context, q *sqlite3.Queries) error {
+	if err := q.DeleteSpeciesNamplaInventory(ctx, int64(sp.id)); err != nil {
+		return err
+	} else if err := q.DeleteSpeciesNamplas(ctx, int64(sp.id)); err != nil {
+		return err
+	}
+	for _, nampla := range sp.namplas {
+		if nampla.planet == nil {
+			continue
+		}
+		err := q.CreateNampla(ctx, sqlite3.CreateNamplaParams{
+			SpeciesID:    int64(sp.id),
+			ID:           int64(nampla.id),
+			PlanetID:     int64(nampla.planet.id),
+			Name:         nampla.name,
+			AUsNeeded:    int64(nampla.AUs_needed),
+			AUsToInstall: int64(nampla.AUs_to_install),
+			IUsNeeded:    int64(nampla.IUs_needed),
+			IUsToInstall: int64(nampla.IUs_to_install),
+			AutoAUs:      int64(nampla.auto_AUs),
+			AutoIUs:      int64(nampla.auto_IUs),
+			Hidden:       boolToInt64(nampla.hidden),
+			Hiding:       boolToInt64(nampla.hiding),
+			MaBase:       int64(nampla.ma_base),
+			Message:      int64(nampla.message),
+			MiBase:       int64(nampla.mi_base),
+			PopUnits:     int64(nampla.pop_units),
+			Recruited:    int64(nampla.recruited),
+			Shipyards:    int64(nampla.shipyards),
+			SiegeEff:     int64(nampla.siege_eff),
+			Special:      int64(nampla.special),
+			Status:       int64(nampla.status),
+			UseOnAmbush:  int64(nampla.use_on_ambush),
+		})
+		if err != nil {
+			return err
+		}
+		for item, quantity := range nampla.item_quantity {
+			if quantity == 0 {
+				continue
+			}
+			err := q.CreateNamplaInventory(ctx, sqlite3.CreateNamplaInventoryParams{
+				SpeciesID: int64(sp.id),
+				NamplaID:  int64(nampla.id),
+				ItemID:    int64(item),
+				Quantity:  int64(quantity),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// save_ships stores the species' ships and the items they carry.
+// The ships must have been removed from the database by save_species.
+func (sp *species_data_t) save_ships(ctx context.Context, q *sqlite3.Queries) error {
+	for _, ship := range sp.ships {
+		err := q.CreateShip(ctx, sqlite3.CreateShipParams{
+			ID:                 int64(ship.id),
+			SpeciesID:          int64(sp.id),
+			Name:               ship.name,
+			X:                  int64(ship.x),
+			Y:                  int64(ship.y),
+			Z:                  int64(ship.z),
+			Pn:                 int64(ship.pn),
+			Age:                int64(ship.age),
+			ArrivedViaWormhole: boolToInt64(ship.arrived_via_wormhole),
+			Class:              int64(ship.class),
+			Damage:             int64(ship.damage),
+			DestX:              int64(ship.dest_x),
+			DestY:              int64(ship.dest_y),
+			DestZ:              int64(ship.dest_z),
+			JustJumped:         boolToInt64(ship.just_jumped),
+			LoadingPoint:       int64(ship.loading_point),
+			RemainingCost:      int64(ship.remaining_cost),
+			Special:            int64(ship.special),
+			Status:             int64(ship.status),
+			Tonnage:            int64(ship.tonnage),
+			Type:               int64(ship.type_),
+			UnloadingPoint:     int64(ship.unloading_point),
+		})
+		if err != nil {
+			return err
+		}
+		for item, quantity := range ship.item_quantity {
+			if quantity == 0 {
+				continue
+			}
+			err := q.CreateShipInventory(ctx, sqlite3.CreateShipInventoryParams{
+				SpeciesID: int64(sp.id),
+				ShipID:    int64(ship.id),
+				ItemID:    int64(item),
+				Quantity:  int64(quantity),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/playbymail/fhgo/sqlc/sqlite3"
+	"strings"
+)
+
+// LoadGalaxy reads the galaxy for the current turn, ready for a phase of the turn to be run.
+func LoadGalaxy(ctx context.Context, q *sqlite3.Queries) (*GalaxyData, error) {
+	return load_galaxy(ctx, q)
+}
+
+// Save persists the galaxy after a phase of the turn.
+func (g *GalaxyData) Save(ctx context.Context, q *sqlite3.Queries) error {
+	return g.save_turn(ctx, q)
+}
+
+// CombatOrders records the battles requested in the COMBAT section of every species' orders.
+func (g *GalaxyData) CombatOrders(ctx context.Context, q *sqlite3.Queries) error {
+	return g.run_orders(ctx, q, COMBAT_SECTION)
+}
+
+// StrikeOrders records the battles requested in the STRIKES section of every
+// species' orders. Strikes are fought after the jumps, so ships that jumped
+// in can be ambushed by the species that spent on an ambush in production.
+func (g *GalaxyData) StrikeOrders(ctx context.Context, q *sqlite3.Queries) error {
+	return g.run_orders(ctx, q, STRIKE_SECTION)
+}
+
+// FightBattles fights the battles recorded by CombatOrders or StrikeOrders.
+func (g *GalaxyData) FightBattles() {
+	g.do_battles()
+}
+
+// RunPreDeparture runs the pre-departure phase of the current turn.
+func RunPreDeparture(ctx context.Context, q *sqlite3.Queries) error {
+	return run_phase(ctx, q, PRE_DEPARTURE_SECTION)
+}
+
+// RunJumps runs the jump phase of the current turn.
+func RunJumps(ctx context.Context, q *sqlite3.Queries) error {
+	return run_phase(ctx, q, JUMP_SECTION)
+}
+
+// RunProduction runs the production phase of the current turn.
+func RunProduction(ctx context.Context, q *sqlite3.Queries) error {
+	return run_phase(ctx, q, PRODUCTION_SECTION)
+}
+
+// RunPostArrival runs the post-arrival phase of the current turn.
+func RunPostArrival(ctx context.Context, q *sqlite3.Queries) error {
+	return run_phase(ctx, q, POST_ARRIVAL_SECTION)
+}
+
+// FinishTurn runs the end-of-turn updates, saves the turn and starts the next one.
+func FinishTurn(ctx context.Context, q *sqlite3.Queries) error {
+	g, err := load_galaxy(ctx, q)
+	if err != nil {
+		return err
+	} else if err := g.start_phase("FINISH"); err != nil {
+		return err
+	}
+	g.finish_turn()
+	return q.InTx(ctx, func(q *sqlite3.Queries) error {
+		if err := g.save_turn(ctx, q); err != nil {
+			return err
+		}
+		g.turn_number++
+		return g.save_galaxy(ctx, q)
+	})
+}
+
+// run_phase loads the galaxy, executes a section of every species' orders and saves the result.
+// A phase can only be run once a turn.
+func run_phase(ctx context.Context, q *sqlite3.Queries, section order_section_e) error {
+	g, err := load_galaxy(ctx, q)
+	if err != nil {
+		return err
+	}
+	if err := g.run_orders(ctx, q, section); err != nil {
+		return err
+	}
+	return g.save_turn(ctx, q)
+}
+
+// start_phase records the phase as the one being run.
+// Returns an error if the phase has already been run this turn.
+func (g *galaxy_data_t) start_phase(phase string) error {
+	if g.phases[phase] {
+		return fmt.Errorf("turn %d: the %s phase has already been run", g.turn_number, phase)
+	}
+	g.phase = phase
+	return nil
+}
+
+// run_orders starts the phase for a section and executes that section of the
+// orders every species submitted for the turn.
+func (g *galaxy_data_t) run_orders(ctx context.Context, q *sqlite3.Queries, section order_section_e) error {
+	if err := g.start_phase(section.String()); err != nil {
+		return err
+	}
+	for _, sp := range g.species {
+		orders, err := g.load_orders(ctx, q, sp)
+		if err != nil {
+			return err
+		}
+		switch section {
+		case COMBAT_SECTION, STRIKE_SECTION:
+			g.do_combat_orders(sp, orders[section])
+		case PRE_DEPARTURE_SECTION:
+			g.do_pre_departure_orders(sp, orders[section])
+		case JUMP_SECTION:
+			g.do_jump_orders(sp, orders[section])
+		case PRODUCTION_SECTION:
+			g.do_production_orders(sp, orders[section])
+		case POST_ARRIVAL_SECTION:
+			g.do_post_arrival_orders(sp, orders[section])
+		}
+	}
+	return nil
+}
+
+// load_orders reads and parses the orders stored for the species for the
+// current turn. A species that has no orders stored gets an empty set.
+func (g *galaxy_data_t) load_orders(ctx context.Context, q *sqlite3.Queries, sp *species_data_t) (orders_t, error) {
+	row, err := q.GetSpeciesOrders(ctx, sqlite3.GetSpeciesOrdersParams{TurnNumber: int64(g.turn_number), SpeciesID: int64(sp.id)})
+	if errors.Is(err, sql.ErrNoRows) {
+		return orders_t{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	orders, err := parse_orders(strings.NewReader(row.Orders))
+	if err != nil {
+		return nil, fmt.Errorf("species %d: orders: %w", sp.id, err)
+	}
+	return orders, nil
+}
@@ -453,3 +453,36 @@ func (p *planet_data_t) approximateLSN(temperature_class, pressure_class int, at
 }
 
 var potential_home_system = false
+
+// life_support_needed returns the life support needed for a species to live on a planet.
+// It compares the planet against the species' home planet and atmospheric requirements.
+func life_support_needed(species *species_data_t, home, colony *planet_data_t) int {
+	// 3 points per temperature and pressure class
+	delta_temperature := colony.temperature_class - home.temperature_class
+	if delta_temperature < 0 {
+		delta_temperature = -delta_temperature
+	}
+	delta_pressure := colony.pressure_class - home.pressure_class
+	if delta_pressure < 0 {
+		delta_pressure = -delta_pressure
+	}
+	ls_needed := 3*delta_temperature + 3*delta_pressure
+
+	// assume that the required gas is not present
+	ls_needed += 3
+	for j, gas := range colony.gas {
+		if colony.gas_percent[j] == 0 {
+			continue
+		}
+		for _, poison := range species.poison_gas {
+			if poison != GAS_NONE && poison == gas {
+				ls_needed += 3
+			}
+		}
+		if gas == species.required_gas && species.required_gas_min <= colony.gas_percent[j] && colony.gas_percent[j] <= species.required_gas_max {
+			ls_needed -= 3
+		}
+	}
+
+	return ls_needed
+}
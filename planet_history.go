@@ -0,0 +1,178 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"context"
+	"fmt"
+	"github.com/playbymail/fhgo/sqlc/sqlite3"
+	"io"
+)
+
+// RMS_PER_MD_INCREASE is the number of raw material units that must be mined
+// from a mining colony to raise its mining difficulty by 0.01.
+const RMS_PER_MD_INCREASE = 100
+
+// planet_history_t records a change made to a planet after it was generated.
+type planet_history_t struct {
+	turn_number int
+	planet      *planet_data_t
+	species     species_id_t // species that caused the change
+	attribute   string       // e.g. TEMPERATURE_CLASS or ATMOSPHERE
+	old_value   string
+	new_value   string
+	reason      string // TERRAFORM or MINING
+}
+
+// record_planet_change adds a change to a planet to the turn's history.
+func (g *galaxy_data_t) record_planet_change(sp *species_data_t, planet *planet_data_t, attribute, old_value, new_value, reason string) {
+	g.planet_history = append(g.planet_history, &planet_history_t{
+		turn_number: g.turn_number,
+		planet:      planet,
+		species:     sp.id,
+		attribute:   attribute,
+		old_value:   old_value,
+		new_value:   new_value,
+		reason:      reason,
+	})
+}
+
+// update_mining_difficulty raises the mining difficulty of every mining colony.
+//
+// The raw materials mined from a planet accumulate in md_increase. Every
+// RMS_PER_MD_INCREASE units add 0.01 to the mining difficulty; the remainder
+// is carried over to the next turn.
+func (g *galaxy_data_t) update_mining_difficulty() {
+	for _, sp := range g.species {
+		for _, nampla := range sp.namplas {
+			planet := nampla.planet
+			if planet == nil || nampla.status&MINING_COLONY == 0 || planet.md_increase < RMS_PER_MD_INCREASE {
+				continue
+			}
+			old_value := planet.mining_difficulty
+			planet.mining_difficulty += planet.md_increase / RMS_PER_MD_INCREASE
+			planet.md_increase %= RMS_PER_MD_INCREASE
+			g.record_planet_change(sp, planet, "MINING_DIFFICULTY", format_hundredths(old_value), format_hundredths(planet.mining_difficulty), "MINING")
+		}
+	}
+}
+
+// format_hundredths formats a value stored in hundredths, such as mining difficulty, as a decimal.
+func format_hundredths(value int) string {
+	return fmt.Sprintf("%d.%02d", value/100, value%100)
+}
+
+// report_planet_changes adds the turn's changes to the species' planets to its report.
+func (g *galaxy_data_t) report_planet_changes(sp *species_data_t) {
+	header := false
+	for _, nampla := range sp.namplas {
+		for _, h := range g.planet_history {
+			if h.planet != nampla.planet || h.turn_number != g.turn_number {
+				continue
+			}
+			if !header {
+				sp.report.printf("\nPlanet changes:\n")
+				header = true
+			}
+			sp.report.printf("  PL %-20s %-17s %s -> %s  (%s)\n", nampla.name, h.attribute, h.old_value, h.new_value, h.reason)
+		}
+	}
+}
+
+// save_planets persists the planets that can have changed during the turn and
+// replaces the turn's planet history.
+func (g *galaxy_data_t) save_planets(ctx context.Context, q *sqlite3.Queries) error {
+	saved := map[*planet_data_t]bool{}
+	for _, sp := range g.species {
+		for _, nampla := range sp.namplas {
+			planet := nampla.planet
+			if planet == nil || saved[planet] {
+				continue
+			}
+			saved[planet] = true
+			err := q.UpdatePlanet(ctx, sqlite3.UpdatePlanetParams{
+				TemperatureClass: int64(planet.temperature_class),
+				PressureClass:    int64(planet.pressure_class),
+				MiningDifficulty: int64(planet.mining_difficulty),
+				MdIncrease:       int64(planet.md_increase),
+				ID:               int64(planet.id),
+			})
+			if err != nil {
+				return err
+			}
+			if err := q.DeletePlanetAtmosphere(ctx, int64(planet.id)); err != nil {
+				return err
+			}
+			for n, gas := range planet.gas {
+				if gas == GAS_NONE || planet.gas_percent[n] == 0 {
+					continue
+				}
+				err := q.CreatePlanetAtmosphere(ctx, sqlite3.CreatePlanetAtmosphereParams{
+					PlanetID: int64(planet.id),
+					GasID:    int64(gas),
+					Percent:  int64(planet.gas_percent[n]),
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if err := q.DeletePlanetHistory(ctx, int64(g.turn_number)); err != nil {
+		return err
+	}
+	for _, h := range g.planet_history {
+		if h.turn_number != g.turn_number {
+			continue
+		}
+		err := q.CreatePlanetHistory(ctx, sqlite3.CreatePlanetHistoryParams{
+			TurnNumber: int64(h.turn_number),
+			PlanetID:   int64(h.planet.id),
+			SpeciesID:  int64(h.species),
+			Attribute:  h.attribute,
+			OldValue:   h.old_value,
+			NewValue:   h.new_value,
+			Reason:     h.reason,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ShowPlanetHistory writes the timeline of changes made to the planet at the location.
+func ShowPlanetHistory(ctx context.Context, q *sqlite3.Queries, w io.Writer, x, y, z, pn int) error {
+	g := &galaxy_data_t{}
+	if err := g.load_stars(ctx, q); err != nil {
+		return err
+	}
+	star := g.find_star(x, y, z)
+	if star == nil {
+		return fmt.Errorf("there is no star system at x = %d, y = %d, z = %d", x, y, z)
+	} else if pn < 1 || pn > star.num_planets || star.planets[pn] == nil {
+		return fmt.Errorf("there is no planet #%d at x = %d, y = %d, z = %d", pn, x, y, z)
+	}
+	planet := star.planets[pn]
+	rows, err := q.ListPlanetHistory(ctx, int64(planet.id))
+	if err != nil {
+		return err
+	}
+
+	r := &report_t{}
+	r.printf("History of planet #%d at x = %d, y = %d, z = %d:\n\n", pn, x, y, z)
+	if len(rows) == 0 {
+		r.printf("  The planet has not changed since it was generated.\n")
+	} else {
+		r.printf("  Turn  Species  Attribute          Change\n")
+		for _, row := range rows {
+			r.printf("  %4d  %7d  %-17s  %s -> %s  (%s)\n", row.TurnNumber, row.SpeciesID, row.Attribute, row.OldValue, row.NewValue, row.Reason)
+		}
+	}
+	r.printf("\nCurrent: temperature class %d, pressure class %d, mining difficulty %s\n",
+		planet.temperature_class, planet.pressure_class, format_hundredths(planet.mining_difficulty))
+	r.printf("         %s\n", planet.atmosphere())
+	_, err = io.WriteString(w, r.String())
+	return err
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"context"
+	"github.com/playbymail/fhgo/sqlc/sqlite3"
+	"io"
+)
+
+// HOME_PLANET_POINT is the loading or unloading point recorded for a ship
+// that loads or unloads colonists at the species' home planet.
+const HOME_PLANET_POINT nampla_id_t = 9999
+
+// population_history_t records the population of a colony at the end of a turn.
+type population_history_t struct {
+	turn_number int
+	species     species_id_t
+	nampla      *nampla_data_t
+	pop_units   int // available population units after growth
+	growth      int // population units added by growth
+	recruited   int // population units recruited as CUs
+}
+
+// colonist_point returns the loading or unloading point for colonists
+// moved to or from the named planet.
+func colonist_point(nampla *nampla_data_t) nampla_id_t {
+	if nampla.status&HOME_PLANET != 0 {
+		return HOME_PLANET_POINT
+	}
+	return nampla.id
+}
+
+// max_population returns the population that a colony can support. The home
+// planet supports HP_AVAILABLE_POP units. Other colonies support one unit for
+// every unit of economic base, less the share that goes to life support.
+func (sp *species_data_t) max_population(nampla *nampla_data_t) int {
+	if nampla.status&HOME_PLANET != 0 {
+		return HP_AVAILABLE_POP
+	}
+	ls_needed := min(life_support_needed(sp, sp.home.planet, nampla.planet), 99)
+	return ((nampla.mi_base + nampla.ma_base) * (100 - ls_needed)) / 100
+}
+
+// population_growth returns the population units a colony gains in a turn.
+// A colony grows by a tenth of the room it has left, less the share of that
+// which goes to life support, and always by at least one unit until it is full.
+func (sp *species_data_t) population_growth(nampla *nampla_data_t) int {
+	room := sp.max_population(nampla) - nampla.pop_units
+	if room <= 0 {
+		return 0
+	}
+	ls_needed := 0
+	if nampla.status&HOME_PLANET == 0 {
+		ls_needed = min(life_support_needed(sp, sp.home.planet, nampla.planet), 99)
+	}
+	return max((room*(100-ls_needed))/1000, 1)
+}
+
+// grow_population adds the turn's growth to the available population of
+// every populated colony and records the result in the population history.
+func (g *galaxy_data_t) grow_population() {
+	for _, sp := range g.species {
+		if sp.home.planet == nil {
+			continue
+		}
+		for _, nampla := range sp.namplas {
+			if nampla.planet == nil || nampla.status&POPULATED == 0 || nampla.status&DISBANDED_COLONY != 0 {
+				continue
+			}
+			growth := sp.population_growth(nampla)
+			nampla.pop_units += growth
+			g.population_history = append(g.population_history, &population_history_t{
+				turn_number: g.turn_number,
+				species:     sp.id,
+				nampla:      nampla,
+				pop_units:   nampla.pop_units,
+				growth:      growth,
+				recruited:   nampla.recruited,
+			})
+		}
+	}
+}
+
+// report_population adds the turn's population changes of the species' colonies to its report.
+func (g *galaxy_data_t) report_population(sp *species_data_t) {
+	header := false
+	for _, h := range g.population_history {
+		if h.species != sp.id || h.turn_number != g.turn_number || (h.growth == 0 && h.recruited == 0) {
+			continue
+		}
+		if !header {
+			sp.report.printf("\nPopulation:\n")
+			sp.report.printf("  Colony                   Available  Growth  Recruited\n")
+			header = true
+		}
+		sp.report.printf("  PL %-20s %10d  %6d  %9d\n", h.nampla.name, h.pop_units, h.growth, h.recruited)
+	}
+}
+
+// save_population replaces the turn's population history.
+func (g *galaxy_data_t) save_population(ctx context.Context, q *sqlite3.Queries) error {
+	if err := q.DeletePopulationHistory(ctx, int64(g.turn_number)); err != nil {
+		return err
+	}
+	for _, h := range g.population_history {
+		if h.turn_number != g.turn_number {
+			continue
+		}
+		err := q.CreatePopulationHistory(ctx, sqlite3.CreatePopulationHistoryParams{
+			TurnNumber: int64(h.turn_number),
+			SpeciesID:  int64(h.species),
+			NamplaID:   int64(h.nampla.id),
+			Name:       h.nampla.name,
+			PopUnits:   int64(h.pop_units),
+			Growth:     int64(h.growth),
+			Recruited:  int64(h.recruited),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ShowPopulation writes the population history of every colony, or of the
+// colonies of a single species if species_id is not zero.
+func ShowPopulation(ctx context.Context, q *sqlite3.Queries, w io.Writer, species_id int) error {
+	rows, err := q.ListPopulationHistory(ctx)
+	if err != nil {
+		return err
+	}
+
+	r := &report_t{}
+	shown := false
+	var last_species, last_nampla int64
+	for _, row := range rows {
+		if species_id != 0 && row.SpeciesID != int64(species_id) {
+			continue
+		}
+		if !shown || row.SpeciesID != last_species || row.NamplaID != last_nampla {
+			if shown {
+				r.printf("\n")
+			}
+			r.printf("SP %d, PL %s:\n", row.SpeciesID, row.Name)
+			r.printf("  Turn  Available  Growth  Recruited\n")
+			last_species, last_nampla, shown = row.SpeciesID, row.NamplaID, true
+		}
+		r.printf("  %4d  %9d  %6d  %9d\n", row.TurnNumber, row.PopUnits, row.Growth, row.Recruited)
+	}
+	if !shown {
+		r.printf("No population has been recorded.\n")
+	}
+	_, err = io.WriteString(w, r.String())
+	return err
+}
+
+// recruit_colonists takes the population units for the CUs built at the
+// planet where production is being spent. If the CUs were loaded onto a ship,
+// the planet becomes the ship's loading point.
+func (p *production_t) recruit_colonists(quantity int, ship *ship_data_t) {
+	p.nampla.pop_units -= quantity
+	p.nampla.recruited += quantity
+	if ship != nil {
+		ship.loading_point = colonist_point(p.nampla)
+	}
+}
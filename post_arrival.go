@@ -0,0 +1,35 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+// do_post_arrival_orders executes the orders in the POST-ARRIVAL section of a species' orders.
+// Units unloaded on the species' colonies are installed once all the orders have been executed.
+func (g *galaxy_data_t) do_post_arrival_orders(sp *species_data_t, orders []*order_t) {
+	for _, o := range orders {
+		switch o.command {
+		case ALLY, ENEMY, NEUTRAL:
+			g.do_diplomacy_command(sp, o)
+		case INSTALL:
+			sp.do_install_command(o)
+		case LAND:
+			g.do_land_command(sp, o)
+		case MESSAGE:
+			g.do_message_command(sp, o)
+		case NAME:
+			g.do_name_command(sp, o)
+		case SCAN:
+			g.do_scan_command(sp, o)
+		case TELESCOPE:
+			g.do_telescope_command(sp, o)
+		case TERRAFORM:
+			g.do_terraform_command(sp, o)
+		case TRANSFER:
+			g.do_transfer_command(sp, o)
+		case UNLOAD:
+			sp.do_unload_command(o)
+		default:
+			sp.report.order_ignored(o, "Invalid post-arrival command.")
+		}
+	}
+	sp.auto_install()
+}
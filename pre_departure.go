@@ -0,0 +1,29 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+// do_pre_departure_orders executes the orders in the PRE-DEPARTURE section of a species' orders.
+func (g *galaxy_data_t) do_pre_departure_orders(sp *species_data_t, orders []*order_t) {
+	for _, o := range orders {
+		switch o.command {
+		case ALLY, ENEMY, NEUTRAL:
+			g.do_diplomacy_command(sp, o)
+		case AUTO:
+			sp.do_auto_command(o)
+		case DISBAND:
+			g.do_disband_command(sp, o)
+		case MESSAGE:
+			g.do_message_command(sp, o)
+		case REPAIR:
+			sp.do_repair_command(o)
+		case SCAN:
+			g.do_scan_command(sp, o)
+		case TEACH:
+			g.do_teach_command(sp, o)
+		case TRANSFER:
+			g.do_transfer_command(sp, o)
+		default:
+			sp.report.order_ignored(o, "Invalid pre-departure command.")
+		}
+	}
+}
@@ -36,6 +36,11 @@ func SetSeed(seed uint64) {
 	defaultPRNG.seed = seed
 }
 
+// Seed returns the current state of the default PRNG, which can be passed to SetSeed to restore it.
+func Seed() uint64 {
+	return defaultPRNG.seed
+}
+
 func String() string {
 	return fmt.Sprintf("%016x", defaultPRNG.seed)
 }
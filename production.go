@@ -0,0 +1,226 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import "strings"
+
+// production_t holds the state of a PRODUCTION order while the spending orders that follow it are executed.
+type production_t struct {
+	turn_number         int
+	species             *species_data_t
+	nampla              *nampla_data_t
+	raw_material_units  int // RMs produced this turn, after penalties
+	production_capacity int // manufacturing capacity this turn, after penalties
+	balance             int // EUs available for spending at this nampla
+	eu_spending_limit   int // EUs that may still be drawn from the species' treasury
+	treasury_transfer   int // EUs a mining or resort colony sent to the treasury this turn
+	shipyard_capacity   int // shipyards not yet used this turn
+}
+
+// do_production_orders executes the orders in the PRODUCTION section of a species' orders.
+// A named planet can only produce once a turn.
+func (g *galaxy_data_t) do_production_orders(sp *species_data_t, orders []*order_t) {
+	var p *production_t
+	produced := map[*nampla_data_t]bool{}
+	for _, o := range orders {
+		if o.command == PRODUCTION {
+			if p != nil {
+				p.transfer_balance()
+			}
+			p = sp.do_production_command(o, g.turn_number, produced)
+			if p != nil {
+				g.apply_siege(p)
+			}
+			continue
+		}
+		if p == nil {
+			sp.report.order_ignored(o, "A PRODUCTION order must precede this order.")
+			continue
+		}
+		switch o.command {
+		case AMBUSH:
+			p.do_ambush_command(o)
+		case BUILD:
+			p.do_build_command(o)
+		case CONTINUE:
+			p.do_continue_command(o)
+		case ESTIMATE:
+			g.do_estimate_command(p, o)
+		case HIDE:
+			p.do_hide_command(o)
+		case RECYCLE:
+			g.do_recycle_command(p, o)
+		case RESEARCH:
+			p.do_research_command(o)
+		case SEND:
+			g.do_send_command(p, o)
+		case UPGRADE:
+			g.do_upgrade_command(p, o)
+		default:
+			sp.report.order_ignored(o, "Invalid production command.")
+		}
+	}
+	if p != nil {
+		p.transfer_balance()
+	}
+}
+
+// do_production_command starts production at a named planet and records it
+// in produced. Returns nil if the order is invalid or the planet has already
+// produced this turn.
+func (sp *species_data_t) do_production_command(o *order_t, turn_number int, produced map[*nampla_data_t]bool) *production_t {
+	if o.get_class_abbr() != PLANET_ID {
+		sp.report.order_ignored(o, "Invalid or missing planet abbreviation.")
+		return nil
+	}
+	name := o.get_name()
+	nampla := sp.find_nampla(name)
+	if nampla == nil {
+		sp.report.order_ignored(o, "Invalid or missing planet name.")
+		return nil
+	} else if nampla.status&DISBANDED_COLONY != 0 {
+		sp.report.order_ignored(o, "Production is not possible on a disbanded colony.")
+		return nil
+	} else if produced[nampla] {
+		sp.report.order_ignored(o, "Production has already been done on this planet this turn.")
+		return nil
+	}
+	produced[nampla] = true
+	p := sp.start_production(nampla)
+	p.turn_number = turn_number
+	sp.report.printf("\nStart of production on PL %s. (Initial balance is %d.)\n", nampla.name, p.balance)
+	return p
+}
+
+// planet_output returns the raw materials and manufacturing capacity of a
+// named planet for the turn, after the life support penalty and economic
+// efficiency are applied.
+func (sp *species_data_t) planet_output(nampla *nampla_data_t) (raw_material_units, production_capacity int) {
+	planet := nampla.planet
+	if planet.mining_difficulty > 0 {
+		raw_material_units = (10 * sp.tech_level[MI] * nampla.mi_base) / planet.mining_difficulty
+	}
+	production_capacity = (sp.tech_level[MA] * nampla.ma_base) / 10
+
+	production_penalty := 0
+	if ls_needed := life_support_needed(sp, sp.home.planet, planet); ls_needed > 0 {
+		production_penalty = 100
+		if sp.tech_level[LS] > 0 {
+			production_penalty = (100 * ls_needed) / sp.tech_level[LS]
+		}
+		if production_penalty > 100 {
+			production_penalty = 100
+		}
+	}
+	raw_material_units -= (production_penalty * raw_material_units) / 100
+	production_capacity -= (production_penalty * production_capacity) / 100
+	raw_material_units = (planet.econ_efficiency*raw_material_units + 50) / 100
+	production_capacity = (planet.econ_efficiency*production_capacity + 50) / 100
+	return raw_material_units, production_capacity
+}
+
+// start_production computes the raw materials and manufacturing capacity of a
+// named planet and returns the production state for spending.
+//
+// Output is reduced by the life support needed on the planet and scaled by the
+// planet's economic efficiency. Mining and resort colonies can't spend their
+// production locally; two thirds of it is sent to the species' treasury instead.
+func (sp *species_data_t) start_production(nampla *nampla_data_t) *production_t {
+	p := &production_t{species: sp, nampla: nampla}
+	planet := nampla.planet
+	p.raw_material_units, p.production_capacity = sp.planet_output(nampla)
+
+	switch {
+	case nampla.status&MINING_COLONY != 0:
+		p.treasury_transfer = (2 * p.raw_material_units) / 3
+		sp.econ_units += p.treasury_transfer
+		// mining the planet makes it harder to mine in the future
+		planet.md_increase += p.raw_material_units
+	case nampla.status&RESORT_COLONY != 0:
+		p.treasury_transfer = (2 * p.production_capacity) / 3
+		sp.econ_units += p.treasury_transfer
+	default:
+		// raw materials that can't be used this turn are stockpiled
+		available := p.raw_material_units + nampla.item_quantity[RM]
+		if available > p.production_capacity {
+			p.balance = p.production_capacity
+		} else {
+			p.balance = available
+		}
+		nampla.item_quantity[RM] = available - p.balance
+	}
+
+	// a nampla may draw on the treasury for, at most, as much as it produces
+	p.eu_spending_limit = p.balance
+
+	// each shipyard may start one ship per turn
+	p.shipyard_capacity = nampla.shipyards
+
+	return p
+}
+
+// check_bounced spends the amount from the balance, drawing on the species'
+// treasury if the balance is not large enough.
+// Returns true if there are not enough funds; nothing is spent in that case.
+func (p *production_t) check_bounced(amount int) bool {
+	if amount <= p.balance {
+		p.balance -= amount
+		return false
+	}
+	take_from_eus := amount - p.balance
+	if take_from_eus > p.eu_spending_limit || take_from_eus > p.species.econ_units {
+		return true
+	}
+	p.species.econ_units -= take_from_eus
+	p.eu_spending_limit -= take_from_eus
+	p.balance = 0
+	return false
+}
+
+// transfer_balance moves any unspent balance into the species' treasury.
+func (p *production_t) transfer_balance() {
+	if p.balance > 0 {
+		p.species.report.printf("Unspent balance of %d was added to the treasury.\n", p.balance)
+		p.species.econ_units += p.balance
+		p.balance = 0
+	}
+}
+
+// do_research_command executes a RESEARCH order:
+//
+//	RESEARCH amount tech
+//
+// Players sometimes reverse the arguments, so the tech may also come first.
+func (p *production_t) do_research_command(o *order_t) {
+	sp := p.species
+	amount, ok := o.get_value()
+	if o.get_class_abbr() != TECH_ID {
+		sp.report.order_ignored(o, "Invalid or missing tech level abbreviation.")
+		return
+	}
+	tech := tech_level_e(o.abbr_index)
+	if !ok {
+		amount, ok = o.get_value()
+	}
+	if !ok || amount <= 0 {
+		sp.report.order_ignored(o, "Invalid or missing amount to spend.")
+		return
+	}
+	if p.check_bounced(amount) {
+		sp.report.order_ignored(o, "Insufficient funds to execute order.")
+		return
+	}
+	sp.research(tech, amount, p.turn_number)
+	sp.report.printf("Spent %d on %s research.\n", amount, tech)
+}
+
+// find_nampla returns the species' named planet with the given name, or nil if there isn't one.
+// Names are not case-sensitive.
+func (sp *species_data_t) find_nampla(name string) *nampla_data_t {
+	for _, nampla := range sp.namplas {
+		if strings.EqualFold(nampla.name, name) {
+			return nampla
+		}
+	}
+	return nil
+}
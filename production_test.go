@@ -0,0 +1,51 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"strings"
+	"testing"
+)
+
+// production_species returns a species with a home planet and a mining colony.
+func production_species() *species_data_t {
+	home := &planet_data_t{temperature_class: 10, pressure_class: 10, mining_difficulty: 200, econ_efficiency: 100}
+	home.gas[0], home.gas_percent[0] = O2, 20
+	mine := &planet_data_t{temperature_class: 10, pressure_class: 10, mining_difficulty: 100, econ_efficiency: 100}
+	mine.gas[0], mine.gas_percent[0] = O2, 20
+	sp := &species_data_t{id: 1, name: "Alpha", econ_units: 1000}
+	sp.tech_level = [6]int{10, 10, 10, 10, 10, 10}
+	sp.required_gas, sp.required_gas_min, sp.required_gas_max = O2, 10, 30
+	sp.home.planet = home
+	sp.home.nampla = &nampla_data_t{id: 1, name: "Earth", planet: home, mi_base: 500, ma_base: 1000, status: HOME_PLANET | POPULATED}
+	sp.namplas = append(sp.namplas, sp.home.nampla,
+		&nampla_data_t{id: 2, name: "Mine", planet: mine, mi_base: 300, status: COLONY | POPULATED | MINING_COLONY})
+	return sp
+}
+
+// produce runs the PRODUCTION section of the orders for a new production_species.
+func produce(t *testing.T, text string) *species_data_t {
+	t.Helper()
+	orders, err := parse_orders(strings.NewReader("START PRODUCTION\n" + text + "END\n"))
+	if err != nil {
+		t.Fatalf("parse_orders: %v", err)
+	}
+	sp := production_species()
+	(&galaxy_data_t{turn_number: 1}).do_production_orders(sp, orders[PRODUCTION_SECTION])
+	return sp
+}
+
+func TestProductionOnlyOncePerTurn(t *testing.T) {
+	once := produce(t, "PRODUCTION PL Earth\nPRODUCTION PL Mine\n")
+	twice := produce(t, "PRODUCTION PL Earth\nPRODUCTION PL Mine\nPRODUCTION PL Mine\nPRODUCTION PL Earth\n")
+
+	if got := strings.Count(twice.report.String(), "Production has already been done on this planet this turn."); got != 2 {
+		t.Errorf("repeated orders: got %d refused, want 2:\n%s", got, twice.report.String())
+	}
+	if once.econ_units != twice.econ_units {
+		t.Errorf("treasury: got %d, want %d", twice.econ_units, once.econ_units)
+	}
+	if got, want := twice.namplas[1].planet.md_increase, once.namplas[1].planet.md_increase; got != want {
+		t.Errorf("mining difficulty increase: got %d, want %d", got, want)
+	}
+}
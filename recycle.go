@@ -0,0 +1,176 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import "fmt"
+
+// original_cost returns the cost in EUs of building the ship. Sub-light
+// ships and starbases are built at the sub-light price.
+func (s *ship_data_t) original_cost() int {
+	return ship_classes[s.class].cost_of(s.tonnage, s.type_ != FTL)
+}
+
+// upgrade_cost returns the cost in EUs of taking years off the ship's age.
+// Each year costs one fortieth of the cost of building the ship, so bigger
+// ships cost more to upgrade.
+func (s *ship_data_t) upgrade_cost(years int) int {
+	return (years*s.original_cost() + 39) / 40
+}
+
+// recycle_value returns the EUs recovered by recycling the ship. A new ship
+// is worth half its cost, and the value falls with age until it is worthless at 60.
+func (s *ship_data_t) recycle_value() int {
+	return (s.original_cost() * max(60-s.age, 0)) / 120
+}
+
+// get_production_ship parses the name of a ship that must be at the planet
+// where production is being spent. Returns nil, after reporting the problem, if it isn't.
+func (p *production_t) get_production_ship(o *order_t) *ship_data_t {
+	sp := p.species
+	if o.get_class_abbr() != SHIP_CLASS {
+		sp.report.order_ignored(o, "Invalid or missing ship.")
+		return nil
+	}
+	name := o.get_name()
+	ship := sp.find_ship(name)
+	if ship == nil {
+		sp.report.order_ignored(o, fmt.Sprintf("You do not have a ship named %q.", name))
+		return nil
+	} else if ship.status == UNDER_CONSTRUCTION {
+		sp.report.order_ignored(o, fmt.Sprintf("%s is still under construction.", ship.ship_name()))
+		return nil
+	} else if !ship.is_at(p.nampla) {
+		sp.report.order_ignored(o, fmt.Sprintf("%s is not at PL %s.", ship.ship_name(), p.nampla.name))
+		return nil
+	}
+	return ship
+}
+
+// do_upgrade_command executes an UPGRADE order:
+//
+//	UPGRADE ship [, amount]
+//
+// The ship must be in orbit of, or landed on, the planet where production is
+// being spent. Its age is reduced to zero or, if an amount is given, by as
+// many years as the amount will pay for.
+func (g *galaxy_data_t) do_upgrade_command(p *production_t, o *order_t) {
+	sp := p.species
+	ship := p.get_production_ship(o)
+	if ship == nil {
+		return
+	}
+	years := ship.age
+	if amount, ok := o.get_value(); ok {
+		if amount < 1 {
+			sp.report.order_ignored(o, "Invalid amount.")
+			return
+		}
+		years = min(years, (40*amount)/max(ship.original_cost(), 1))
+	}
+	if ship.age == 0 {
+		sp.report.order_ignored(o, fmt.Sprintf("%s is already new.", ship.ship_name()))
+		return
+	} else if years == 0 {
+		sp.report.order_ignored(o, fmt.Sprintf("Upgrading %s by one year costs %d.", ship.ship_name(), ship.upgrade_cost(1)))
+		return
+	}
+	if g.ledger_full() {
+		sp.report.order_ignored(o, "Too many transactions this turn.")
+		return
+	}
+	cost := ship.upgrade_cost(years)
+	if p.check_bounced(cost) {
+		sp.report.order_ignored(o, fmt.Sprintf("Insufficient funds. The order needs %d.", cost))
+		return
+	}
+	ship.repair(years)
+	g.add_transaction(&trans_data_t{
+		type_:     SHIP_UPGRADE,
+		donor:     sp.id,
+		recipient: sp.id,
+		value:     cost,
+		x:         ship.x,
+		y:         ship.y,
+		z:         ship.z,
+		pn:        ship.pn,
+		name1:     ship.ship_name(),
+		number1:   years,
+	})
+	sp.report.printf("%s was upgraded at a cost of %d. Its age is now %d.\n", ship.ship_name(), cost, ship.age)
+}
+
+// do_recycle_command executes a RECYCLE order:
+//
+//	RECYCLE quantity item
+//	RECYCLE ship
+//
+// Items stored on the planet where production is being spent are recycled
+// for half of their cost. A ship at the planet is recycled for its
+// recycle_value, and its cargo is unloaded onto the planet. The EUs recovered
+// are added to the planet's production balance.
+func (g *galaxy_data_t) do_recycle_command(p *production_t, o *order_t) {
+	sp := p.species
+	if quantity, ok := o.get_value(); ok {
+		if quantity < 1 {
+			sp.report.order_ignored(o, "The quantity to recycle must be greater than zero.")
+			return
+		} else if o.get_class_abbr() != ITEM_CLASS {
+			sp.report.order_ignored(o, "Invalid or missing item abbreviation.")
+			return
+		}
+		item := item_e(o.abbr_index)
+		if available := p.nampla.item_quantity[item]; quantity > available {
+			sp.report.order_ignored(o, fmt.Sprintf("PL %s has only %s.", p.nampla.name, item_quantity_name(item, available)))
+			return
+		} else if g.ledger_full() {
+			sp.report.order_ignored(o, "Too many transactions this turn.")
+			return
+		}
+		value := (quantity * items[item].cost) / 2
+		p.nampla.item_quantity[item] -= quantity
+		p.balance += value
+		g.add_transaction(&trans_data_t{
+			type_:     ITEM_RECYCLE,
+			donor:     sp.id,
+			recipient: sp.id,
+			value:     value,
+			x:         p.nampla.x,
+			y:         p.nampla.y,
+			z:         p.nampla.z,
+			pn:        p.nampla.pn,
+			name1:     "PL " + p.nampla.name,
+			number1:   int(item),
+			number2:   quantity,
+		})
+		sp.report.printf("Recycled %s on PL %s for %d.\n", item_quantity_name(item, quantity), p.nampla.name, value)
+		return
+	}
+
+	ship := p.get_production_ship(o)
+	if ship == nil {
+		return
+	} else if g.ledger_full() {
+		sp.report.order_ignored(o, "Too many transactions this turn.")
+		return
+	}
+	value := ship.recycle_value()
+	for item, quantity := range ship.item_quantity {
+		p.nampla.item_quantity[item] += quantity
+	}
+	ship.item_quantity = [MAX_ITEMS]int{}
+	sp.remove_ships([]*ship_data_t{ship})
+	p.balance += value
+	g.add_transaction(&trans_data_t{
+		type_:     SHIP_RECYCLE,
+		donor:     sp.id,
+		recipient: sp.id,
+		value:     value,
+		x:         ship.x,
+		y:         ship.y,
+		z:         ship.z,
+		pn:        ship.pn,
+		name1:     ship.ship_name(),
+		number1:   ship.age,
+	})
+	sp.report.printf("%s was recycled for %d. Its cargo was unloaded on PL %s.\n", ship.ship_name(), value, p.nampla.name)
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DR_TONNAGE_YEARS is the repair done by a single DR unit: one year of age
+// removed from 10,000 tons of ship for every one of these.
+const DR_TONNAGE_YEARS = 16
+
+// dr_pool_t is the DR units available for repairs at a location: those on
+// the ships being repaired and those at a shipyard in the star system.
+type dr_pool_t struct {
+	ships  []*ship_data_t
+	nampla *nampla_data_t // shipyard colony, if any
+}
+
+// at_shipyard returns true if the ship can use the DR units at the pool's shipyard.
+// Only ships in orbit of the shipyard's planet, or landed on it, can use them.
+func (pool *dr_pool_t) at_shipyard(ship *ship_data_t) bool {
+	return pool.nampla != nil && ship.pn == pool.nampla.pn && (ship.status == IN_ORBIT || ship.status == ON_SURFACE)
+}
+
+// available_to returns the number of DR units in the pool that can be used to repair the ship.
+func (pool *dr_pool_t) available_to(ship *ship_data_t) int {
+	total := 0
+	for _, s := range pool.ships {
+		total += s.item_quantity[DR]
+	}
+	if pool.at_shipyard(ship) {
+		total += pool.nampla.item_quantity[DR]
+	}
+	return total
+}
+
+// use removes the DR units used to repair the ship from the pool. Units at
+// the shipyard are used first, then those on the ships, in order.
+func (pool *dr_pool_t) use(ship *ship_data_t, quantity int) {
+	if pool.at_shipyard(ship) {
+		n := min(quantity, pool.nampla.item_quantity[DR])
+		pool.nampla.item_quantity[DR] -= n
+		quantity -= n
+	}
+	for _, s := range pool.ships {
+		n := min(quantity, s.item_quantity[DR])
+		s.item_quantity[DR] -= n
+		quantity -= n
+	}
+}
+
+// do_repair_command executes a REPAIR order:
+//
+//	REPAIR ship [, age]
+//	REPAIR x y z [, age]
+//
+// The first form repairs a single ship with the DR units it carries. The
+// second form pools the DR units on all of the species' ships at the location
+// and repairs the ships there, oldest first. In both forms, ships in orbit of
+// one of the species' colonies that has shipyards can also use the DR units
+// stored at the colony. Ships are repaired down to the given age, or as far as
+// the DR units allow. Repairs remove battle damage first.
+func (sp *species_data_t) do_repair_command(o *order_t) {
+	pool := &dr_pool_t{}
+	var x, y, z int
+	if o.get_class_abbr() == SHIP_CLASS {
+		name := o.get_name()
+		ship := sp.find_ship(name)
+		if ship == nil {
+			sp.report.order_ignored(o, fmt.Sprintf("You do not have a ship named %q.", name))
+			return
+		} else if ship.status == UNDER_CONSTRUCTION {
+			sp.report.order_ignored(o, fmt.Sprintf("%s is still under construction.", ship.ship_name()))
+			return
+		}
+		pool.ships = append(pool.ships, ship)
+		x, y, z = ship.x, ship.y, ship.z
+	} else {
+		var coords [3]int
+		for i := range coords {
+			value, ok := o.get_value()
+			if !ok {
+				sp.report.order_ignored(o, "Invalid or missing ship name or coordinates.")
+				return
+			}
+			coords[i] = value
+		}
+		x, y, z = coords[0], coords[1], coords[2]
+		for _, ship := range sp.ships {
+			if ship.x == x && ship.y == y && ship.z == z && ship.status != UNDER_CONSTRUCTION && ship.status != JUMPED_IN_COMBAT {
+				pool.ships = append(pool.ships, ship)
+			}
+		}
+		if len(pool.ships) == 0 {
+			sp.report.order_ignored(o, fmt.Sprintf("You have no ships at x = %d, y = %d, z = %d.", x, y, z))
+			return
+		}
+	}
+	desired_age := 0
+	if value, ok := o.get_value(); ok {
+		if value < 0 {
+			sp.report.order_ignored(o, "Invalid age.")
+			return
+		}
+		desired_age = value
+	}
+
+	for _, nampla := range sp.namplas {
+		if nampla.x == x && nampla.y == y && nampla.z == z && nampla.shipyards > 0 && nampla.item_quantity[DR] > 0 {
+			pool.nampla = nampla
+			break
+		}
+	}
+
+	ships := append([]*ship_data_t{}, pool.ships...)
+	sort.SliceStable(ships, func(i, j int) bool {
+		return ships[i].age > ships[j].age
+	})
+	repaired := 0
+	for _, ship := range ships {
+		if ship.age <= desired_age {
+			continue
+		}
+		age_reduction := min(ship.age-desired_age, (DR_TONNAGE_YEARS*pool.available_to(ship))/max(ship.tonnage, 1))
+		if age_reduction == 0 {
+			continue
+		}
+		dr_units_used := (age_reduction*ship.tonnage + DR_TONNAGE_YEARS - 1) / DR_TONNAGE_YEARS
+		pool.use(ship, dr_units_used)
+		ship.repair(age_reduction)
+		sp.report.printf("%s was repaired using %s. Its age is now %d.\n",
+			ship.ship_name(), item_quantity_name(DR, dr_units_used), ship.age)
+		repaired++
+	}
+	if repaired == 0 {
+		sp.report.order_ignored(o, "No repairs were possible with the DR units available.")
+	}
+}
+
+// repair reduces the age of the ship. Battle damage is repaired before normal wear.
+func (s *ship_data_t) repair(years int) {
+	s.age -= years
+	s.damage = max(s.damage-years, 0)
+}
+
+// report_fleet adds the age and battle damage of each of the species' ships to its report.
+func (sp *species_data_t) report_fleet() {
+	header := false
+	for _, ship := range sp.ships {
+		if ship.status == UNDER_CONSTRUCTION {
+			continue
+		}
+		if !header {
+			sp.report.printf("\nFleet status:\n")
+			sp.report.printf("  Ship                       Age  Damage  DRs  Location\n")
+			header = true
+		}
+		sp.report.printf("  %-24s %5d  %6d  %3d  x = %d, y = %d, z = %d, planet #%d\n",
+			ship.ship_name(), ship.age, ship.damage, ship.item_quantity[DR], ship.x, ship.y, ship.z, ship.pn)
+	}
+}
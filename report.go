@@ -0,0 +1,26 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// report_t collects the text of a species' status report for the current turn.
+type report_t struct {
+	sb strings.Builder
+}
+
+func (r *report_t) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(&r.sb, format, args...)
+}
+
+// order_ignored logs an order that could not be executed, along with the reason.
+func (r *report_t) order_ignored(o *order_t, reason string) {
+	r.printf("!!! Order ignored:\n!!! %s\n!!! %s\n", o.text, reason)
+}
+
+func (r *report_t) String() string {
+	return r.sb.String()
+}
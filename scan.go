@@ -0,0 +1,271 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"context"
+	"fmt"
+	"github.com/playbymail/fhgo/sqlc/sqlite3"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var gas_name = [...]string{"None", "H2", "CH4", "He", "NH3", "N2", "CO2", "O2", "HCl", "Cl2", "F2", "H2O", "SO2", "H2S"}
+
+func (g gas_e) String() string {
+	if 0 <= g && int(g) < len(gas_name) {
+		return gas_name[g]
+	}
+	return "?"
+}
+
+// star_color_char is the spectral class shown for each star color.
+var star_color_char = [...]byte{' ', 'O', 'B', 'A', 'F', 'G', 'K', 'M'}
+
+// stellar_type returns the classic three character stellar type: the star
+// type, the spectral class of its color and its size. For example, "dM3".
+func (s *star_data_t) stellar_type() string {
+	color := byte('?')
+	if 0 <= s.color && int(s.color) < len(star_color_char) {
+		color = star_color_char[s.color]
+	}
+	return fmt.Sprintf("%c%c%d", byte(s.type_), color, s.size)
+}
+
+// atmosphere returns the gases in the planet's atmosphere, with their percentages.
+func (p *planet_data_t) atmosphere() string {
+	var gases []string
+	for n, gas := range p.gas {
+		if gas != GAS_NONE && p.gas_percent[n] > 0 {
+			gases = append(gases, fmt.Sprintf("%s(%d%%)", gas, p.gas_percent[n]))
+		}
+	}
+	if len(gases) == 0 {
+		return "No atmosphere"
+	}
+	return strings.Join(gases, ",")
+}
+
+// do_scan_command executes a SCAN order:
+//
+//	SCAN ship
+//
+// The ship reports on the star system it is in.
+func (g *galaxy_data_t) do_scan_command(sp *species_data_t, o *order_t) {
+	if o.get_class_abbr() != SHIP_CLASS {
+		sp.report.order_ignored(o, "Invalid or missing ship.")
+		return
+	}
+	name := o.get_name()
+	ship := sp.find_ship(name)
+	if ship == nil {
+		sp.report.order_ignored(o, fmt.Sprintf("You do not have a ship named %q.", name))
+		return
+	} else if ship.status == UNDER_CONSTRUCTION {
+		sp.report.order_ignored(o, fmt.Sprintf("%s is still under construction.", ship.ship_name()))
+		return
+	}
+	star := g.find_star(ship.x, ship.y, ship.z)
+	if star == nil {
+		sp.report.order_ignored(o, fmt.Sprintf("There is no star system at x = %d, y = %d, z = %d.", ship.x, ship.y, ship.z))
+		return
+	}
+	if star.visited_by == nil {
+		star.visited_by = map[species_id_t]bool{}
+	}
+	star.visited_by[sp.id] = true
+	sp.report.printf("\nScan of star system by %s:\n\n", ship.ship_name())
+	g.scan(&sp.report, sp, star)
+}
+
+// scan adds the classic star system report to a report. The life support
+// needed on each planet is shown for the scanning species. If the species is
+// nil, the scan is for the game master and life support isn't shown.
+// Colonies are listed unless they are hidden from the scanning species. Alien
+// ships are listed as the scanning species sees them, so disguised ships are not named.
+func (g *galaxy_data_t) scan(r *report_t, sp *species_data_t, star *star_data_t) {
+	r.printf("Coordinates: x = %d, y = %d, z = %d   stellar type = %s   %d planets.\n\n",
+		star.x, star.y, star.z, star.stellar_type(), star.num_planets)
+	if star.worm_here {
+		r.printf("This star system is the terminus of a natural wormhole.\n\n")
+	}
+	r.printf("               Temp  Press Mining\n")
+	r.printf("  #  Dia  Grav Class Class  Diff  LSN  Atmosphere\n")
+	r.printf(" ---------------------------------------------------------------------\n")
+	for pn := 1; pn <= star.num_planets; pn++ {
+		planet := star.planets[pn]
+		if planet == nil {
+			continue
+		}
+		lsn := "  -"
+		if sp != nil && sp.home.planet != nil {
+			lsn = fmt.Sprintf("%3d", life_support_needed(sp, sp.home.planet, planet))
+		}
+		r.printf("  %d  %3d  %d.%02d  %2d    %2d    %d.%02d  %s  %s\n",
+			pn, planet.diameter, planet.gravity/100, planet.gravity%100,
+			planet.temperature_class, planet.pressure_class,
+			planet.mining_difficulty/100, planet.mining_difficulty%100,
+			lsn, planet.atmosphere())
+	}
+
+	header := false
+	for _, owner := range g.species {
+		for _, nampla := range owner.namplas {
+			if nampla.x != star.x || nampla.y != star.y || nampla.z != star.z || nampla.status&POPULATED == 0 {
+				continue
+			} else if owner != sp && (nampla.hidden || nampla.hiding) {
+				continue
+			}
+			if !header {
+				r.printf("\nColonies:\n")
+				header = true
+			}
+			r.printf("  %d  PL %s of SP %s\n", nampla.pn, nampla.name, owner.name)
+		}
+	}
+
+	header = false
+	for _, owner := range g.species {
+		if owner == sp {
+			continue
+		}
+		for _, ship := range owner.ships {
+			if ship.x != star.x || ship.y != star.y || ship.z != star.z || ship.status == UNDER_CONSTRUCTION {
+				continue
+			}
+			if !header {
+				r.printf("\nAlien ships:\n")
+				header = true
+			}
+			if sp == nil {
+				r.printf("  %s\n", ship_view_t{ship: ship, owner: owner})
+			} else {
+				r.printf("  %s\n", ship_view_t{ship: ship, owner: owner}.observed_by(sp))
+			}
+		}
+	}
+	r.printf("\n")
+}
+
+// scan_near returns the star systems within the radius of the location, nearest first.
+func (g *galaxy_data_t) scan_near(x, y, z, radius int) []scan_system_t {
+	var systems []scan_system_t
+	for _, star := range g.stars {
+		if distance := star.distanceTo(x, y, z); distance <= float64(radius) {
+			systems = append(systems, scan_system_t{star: star, distance: distance})
+		}
+	}
+	sort.Slice(systems, func(i, j int) bool {
+		if systems[i].distance != systems[j].distance {
+			return systems[i].distance < systems[j].distance
+		}
+		a, b := systems[i].star, systems[j].star
+		return coord_t{x: a.x, y: a.y, z: a.z}.Less(coord_t{x: b.x, y: b.y, z: b.z})
+	})
+	return systems
+}
+
+// load_stars reads the star systems and their planets.
+func (g *galaxy_data_t) load_stars(ctx context.Context, q *sqlite3.Queries) error {
+	rows, err := q.ListStars(ctx)
+	if err != nil {
+		return err
+	}
+	g.stars = nil
+	stars := map[int64]*star_data_t{}
+	for _, row := range rows {
+		color, _ := strconv.Atoi(row.Color)
+		star := &star_data_t{
+			id:    star_id_t(row.ID),
+			x:     int(row.X),
+			y:     int(row.Y),
+			z:     int(row.Z),
+			color: star_color_e(color),
+			size:  int(row.Size),
+			type_: UNKNOWN_STAR_TYPE,
+		}
+		if row.Type != "" {
+			star.type_ = star_type_e(row.Type[0])
+		}
+		g.stars = append(g.stars, star)
+		stars[row.ID] = star
+	}
+
+	planets := map[int64]*planet_data_t{}
+	prows, err := q.ListPlanets(ctx)
+	if err != nil {
+		return err
+	}
+	for _, row := range prows {
+		star, ok := stars[row.StarID]
+		if !ok || row.Pn < 1 || row.Pn > 9 {
+			return fmt.Errorf("planet %d: invalid star %d or orbit %d", row.ID, row.StarID, row.Pn)
+		}
+		planet := &planet_data_t{
+			id:                planet_id_t(row.ID),
+			diameter:          int(row.Diameter),
+			econ_efficiency:   int(row.EconEfficiency),
+			gravity:           int(row.Gravity),
+			md_increase:       int(row.MdIncrease),
+			mining_difficulty: int(row.MiningDifficulty),
+			pressure_class:    int(row.PressureClass),
+			temperature_class: int(row.TemperatureClass),
+			star:              star,
+			orbit:             int(row.Pn),
+		}
+		star.planets[row.Pn] = planet
+		star.num_planets = max(star.num_planets, int(row.Pn))
+		planets[row.ID] = planet
+	}
+
+	arows, err := q.ListPlanetAtmospheres(ctx)
+	if err != nil {
+		return err
+	}
+	gases := map[int64]int{}
+	for _, row := range arows {
+		planet, ok := planets[row.PlanetID]
+		if !ok || gases[row.PlanetID] >= len(planet.gas) {
+			continue
+		}
+		n := gases[row.PlanetID]
+		planet.gas[n], planet.gas_percent[n] = gas_e(row.GasID), int(row.Percent)
+		gases[row.PlanetID]++
+	}
+	return nil
+}
+
+// ScanSystem writes the game master's scan of the star system at the location.
+func ScanSystem(ctx context.Context, q *sqlite3.Queries, w io.Writer, x, y, z int) error {
+	g := &galaxy_data_t{}
+	if err := g.load_stars(ctx, q); err != nil {
+		return err
+	}
+	star := g.find_star(x, y, z)
+	if star == nil {
+		return fmt.Errorf("there is no star system at x = %d, y = %d, z = %d", x, y, z)
+	}
+	r := &report_t{}
+	g.scan(r, nil, star)
+	_, err := io.WriteString(w, r.String())
+	return err
+}
+
+// ScanNear writes the list of star systems within the radius of the location, nearest first.
+func ScanNear(ctx context.Context, q *sqlite3.Queries, w io.Writer, x, y, z, radius int) error {
+	g := &galaxy_data_t{}
+	if err := g.load_stars(ctx, q); err != nil {
+		return err
+	}
+	r := &report_t{}
+	r.printf("Star systems within %d parsecs of x = %d, y = %d, z = %d:\n\n", radius, x, y, z)
+	r.printf("  Distance     X     Y     Z  Type  Planets\n")
+	for _, system := range g.scan_near(x, y, z, radius) {
+		star := system.star
+		r.printf("  %8.2f  %4d  %4d  %4d  %s  %7d\n", system.distance, star.x, star.y, star.z, star.stellar_type(), star.num_planets)
+	}
+	_, err := io.WriteString(w, r.String())
+	return err
+}
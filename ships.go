@@ -0,0 +1,357 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"github.com/playbymail/fhgo/sqlc/sqlite3"
+	"strconv"
+	"strings"
+)
+
+var (
+	//go:embed catalogs/ship_classes.json
+	shipClassesJSON []byte
+)
+
+// ship_class_t describes a class of ship.
+//
+// Transports and starbases are built to order; their tonnage is given when they
+// are built and their cost and tech requirement scale with it.
+type ship_class_t struct {
+	class            ship_class_e
+	abbr             string
+	name             string
+	tonnage          int          // tonnage divided by 10,000. The minimum tonnage for classes built to order
+	cost             int          // cost in EUs of an FTL ship. The cost per unit of tonnage for classes built to order
+	variable_tonnage bool         // set if the tonnage is given when the ship is built
+	sub_light_only   bool         // set if the class can't be built with FTL drives
+	needs_shipyard   bool         // set if the class must be built in a shipyard
+	cargo_factor     int          // carrying capacity per unit of tonnage
+	cargo_growth     int          // if not zero, capacity per unit of tonnage grows by one for every this many units of tonnage
+	tech             tech_level_e // tech needed to build the class
+	min_level        int          // minimum tech level per unit of tonnage
+}
+
+// ship_classes is the catalog of ship classes, indexed by class.
+var ship_classes = load_ship_classes(shipClassesJSON)
+
+// load_ship_classes parses a ship class catalog.
+// It panics if the catalog is invalid, since the catalog is embedded in the binary.
+func load_ship_classes(data []byte) [NUM_SHIP_CLASSES]*ship_class_t {
+	var input []struct {
+		Class           string `json:"class"`
+		Name            string `json:"name"`
+		Tonnage         int    `json:"tonnage"`
+		Cost            int    `json:"cost"`
+		VariableTonnage bool   `json:"variable_tonnage"`
+		SubLightOnly    bool   `json:"sub_light_only"`
+		NeedsShipyard   bool   `json:"needs_shipyard"`
+		CargoFactor     int    `json:"cargo_factor"`
+		CargoGrowth     int    `json:"cargo_growth"`
+		Tech            string `json:"tech"`
+		MinLevel        int    `json:"min_level"`
+	}
+	if err := json.Unmarshal(data, &input); err != nil {
+		panic(fmt.Sprintf("ship classes: %v", err))
+	} else if len(input) != int(NUM_SHIP_CLASSES) {
+		panic(fmt.Sprintf("ship classes: want %d classes, got %d", NUM_SHIP_CLASSES, len(input)))
+	}
+	var classes [NUM_SHIP_CLASSES]*ship_class_t
+	for n, sc := range input {
+		tech, ok := tech_from_abbr(sc.Tech)
+		if !ok {
+			panic(fmt.Sprintf("ship classes: %s: invalid tech %q", sc.Class, sc.Tech))
+		} else if sc.Tonnage < 1 || sc.Cost < 1 {
+			panic(fmt.Sprintf("ship classes: %s: tonnage and cost must be positive", sc.Class))
+		}
+		classes[n] = &ship_class_t{
+			class:            ship_class_e(n),
+			abbr:             sc.Class,
+			name:             sc.Name,
+			tonnage:          sc.Tonnage,
+			cost:             sc.Cost,
+			variable_tonnage: sc.VariableTonnage,
+			sub_light_only:   sc.SubLightOnly,
+			needs_shipyard:   sc.NeedsShipyard,
+			cargo_factor:     sc.CargoFactor,
+			cargo_growth:     sc.CargoGrowth,
+			tech:             tech,
+			min_level:        sc.MinLevel,
+		}
+	}
+	return classes
+}
+
+func (c ship_class_e) String() string {
+	if c < 0 || c >= NUM_SHIP_CLASSES {
+		return "??"
+	}
+	return ship_classes[c].abbr
+}
+
+// cost_of returns the cost in EUs to build a ship of the class.
+// Sub-light ships cost 25% less than FTL ships.
+func (sc *ship_class_t) cost_of(tonnage int, sub_light bool) int {
+	cost := sc.cost
+	if sc.variable_tonnage {
+		cost = sc.cost * tonnage
+	}
+	if sub_light {
+		cost = (3 * cost) / 4
+	}
+	return cost
+}
+
+// capacity_of returns the carrying capacity of a ship of the class.
+func (sc *ship_class_t) capacity_of(tonnage int) int {
+	if sc.cargo_growth == 0 {
+		return sc.cargo_factor * tonnage
+	}
+	return (sc.cargo_factor + tonnage/sc.cargo_growth) * tonnage
+}
+
+// min_tech_level returns the tech level needed to build a ship of the class.
+func (sc *ship_class_t) min_tech_level(tonnage int) int {
+	return sc.min_level * tonnage
+}
+
+// ship_name returns the name of a ship as shown in reports, e.g. "TR10S Nina".
+func (s *ship_data_t) ship_name() string {
+	sc := ship_classes[s.class]
+	name := sc.abbr
+	if sc.variable_tonnage {
+		name += strconv.Itoa(s.tonnage)
+	}
+	if s.type_ == SUB_LIGHT && !sc.sub_light_only {
+		name += "S"
+	}
+	return name + " " + s.name
+}
+
+// capacity returns the carrying capacity of the ship.
+func (s *ship_data_t) capacity() int {
+	return ship_classes[s.class].capacity_of(s.tonnage)
+}
+
+// find_ship returns the species' ship with the given name, or nil if there isn't one.
+// Names are not case-sensitive.
+func (sp *species_data_t) find_ship(name string) *ship_data_t {
+	for _, ship := range sp.ships {
+		if strings.EqualFold(ship.name, name) {
+			return ship
+		}
+	}
+	return nil
+}
+
+// get_ship_class_abbr parses a ship class abbreviation, such as "DD", "DDS" or "TR10S".
+// Returns false if the token is not a ship class.
+func (o *order_t) get_ship_class_abbr(abbr string) bool {
+	if len(abbr) < 2 {
+		return false
+	}
+	class := ship_class_e(-1)
+	for _, sc := range ship_classes {
+		if abbr[:2] == sc.abbr {
+			class = sc.class
+			break
+		}
+	}
+	if class == -1 {
+		return false
+	}
+	rest := abbr[2:]
+	sub_light := strings.HasSuffix(rest, "S")
+	if sub_light {
+		rest = rest[:len(rest)-1]
+	}
+	tonnage := 0
+	if rest != "" {
+		n, err := strconv.Atoi(rest)
+		if err != nil || n < 1 {
+			return false
+		}
+		tonnage = n
+	}
+	o.abbr_type, o.abbr_index, o.sub_light, o.tonnage = SHIP_CLASS, int(class), sub_light, tonnage
+	return true
+}
+
+// do_build_command executes a BUILD order for a ship:
+//
+//	BUILD class name [, amount]
+//
+// If the amount is less than the cost of the ship, the ship is left under
+// construction and must be finished with CONTINUE orders on later turns.
+// Orders that start with a quantity are for items.
+func (p *production_t) do_build_command(o *order_t) {
+	sp := p.species
+	if quantity, ok := o.get_value(); ok {
+		p.do_build_item_command(o, quantity)
+		return
+	}
+	if o.get_class_abbr() != SHIP_CLASS {
+		sp.report.order_ignored(o, "Invalid or missing ship class.")
+		return
+	}
+	sc := ship_classes[o.abbr_index]
+	tonnage, sub_light := sc.tonnage, o.sub_light || sc.sub_light_only
+	if sc.variable_tonnage {
+		if o.tonnage == 0 {
+			sp.report.order_ignored(o, fmt.Sprintf("The tonnage of a %s must be given, e.g. %s%d.", sc.name, sc.abbr, sc.tonnage))
+			return
+		}
+		tonnage = o.tonnage
+	} else if o.tonnage != 0 {
+		sp.report.order_ignored(o, fmt.Sprintf("A %s has a fixed tonnage of %d.", sc.name, sc.tonnage))
+		return
+	}
+	if min_level := sc.min_tech_level(tonnage); sp.tech_level[sc.tech] < min_level {
+		sp.report.order_ignored(o, fmt.Sprintf("Building a %s of %d tons requires %s %d, but your %s is %d.", sc.name, tonnage*10_000, tech_abbr[sc.tech], min_level, tech_abbr[sc.tech], sp.tech_level[sc.tech]))
+		return
+	}
+	if sc.needs_shipyard {
+		if p.nampla.shipyards == 0 {
+			sp.report.order_ignored(o, fmt.Sprintf("PL %s has no shipyards.", p.nampla.name))
+			return
+		} else if p.shipyard_capacity < 1 {
+			sp.report.order_ignored(o, fmt.Sprintf("All %d shipyards on PL %s are already in use this turn.", p.nampla.shipyards, p.nampla.name))
+			return
+		}
+	}
+	name := o.get_name()
+	if name == "" {
+		sp.report.order_ignored(o, "Missing ship name.")
+		return
+	} else if sp.find_ship(name) != nil {
+		sp.report.order_ignored(o, fmt.Sprintf("You already have a ship named %q.", name))
+		return
+	}
+	cost := sc.cost_of(tonnage, sub_light)
+	amount := cost
+	if value, ok := o.get_value(); ok {
+		if value < 1 {
+			sp.report.order_ignored(o, "The amount to spend must be greater than zero.")
+			return
+		}
+		amount = min(value, cost)
+	}
+	if p.check_bounced(amount) {
+		sp.report.order_ignored(o, fmt.Sprintf("Insufficient funds. The order needs %d.", amount))
+		return
+	}
+	if sc.needs_shipyard {
+		p.shipyard_capacity--
+	}
+
+	ship := &ship_data_t{
+		name:           name,
+		x:              p.nampla.x,
+		y:              p.nampla.y,
+		z:              p.nampla.z,
+		pn:             p.nampla.pn,
+		status:         IN_ORBIT,
+		type_:          FTL,
+		class:          sc.class,
+		tonnage:        tonnage,
+		remaining_cost: cost - amount,
+	}
+	if sc.sub_light_only {
+		ship.type_ = STARBASE
+	} else if sub_light {
+		ship.type_ = SUB_LIGHT
+	}
+	if ship.remaining_cost > 0 {
+		ship.status = UNDER_CONSTRUCTION
+	}
+	sp.ships = append(sp.ships, ship)
+	sp.num_ships = len(sp.ships)
+
+	if ship.status == UNDER_CONSTRUCTION {
+		sp.report.printf("Started construction of %s at a cost of %d. %d remains to be paid.\n", ship.ship_name(), amount, ship.remaining_cost)
+	} else {
+		sp.report.printf("Built %s at a cost of %d.\n", ship.ship_name(), amount)
+	}
+}
+
+// do_continue_command executes a CONTINUE order for a ship under construction:
+//
+//	CONTINUE class name [, amount]
+//
+// If no amount is given, the rest of the cost is paid.
+func (p *production_t) do_continue_command(o *order_t) {
+	sp := p.species
+	if o.get_class_abbr() != SHIP_CLASS {
+		sp.report.order_ignored(o, "Invalid or missing ship class.")
+		return
+	}
+	name := o.get_name()
+	ship := sp.find_ship(name)
+	if ship == nil {
+		sp.report.order_ignored(o, fmt.Sprintf("You do not have a ship named %q.", name))
+		return
+	} else if ship.class != ship_class_e(o.abbr_index) {
+		sp.report.order_ignored(o, fmt.Sprintf("%s is not a %s.", ship.ship_name(), ship_classes[o.abbr_index].name))
+		return
+	} else if ship.status != UNDER_CONSTRUCTION {
+		sp.report.order_ignored(o, fmt.Sprintf("%s is not under construction.", ship.ship_name()))
+		return
+	} else if ship.x != p.nampla.x || ship.y != p.nampla.y || ship.z != p.nampla.z || ship.pn != p.nampla.pn {
+		sp.report.order_ignored(o, fmt.Sprintf("%s is not being built at PL %s.", ship.ship_name(), p.nampla.name))
+		return
+	}
+	amount := ship.remaining_cost
+	if value, ok := o.get_value(); ok {
+		if value < 1 {
+			sp.report.order_ignored(o, "The amount to spend must be greater than zero.")
+			return
+		}
+		amount = min(value, ship.remaining_cost)
+	}
+	if p.check_bounced(amount) {
+		sp.report.order_ignored(o, fmt.Sprintf("Insufficient funds. The order needs %d.", amount))
+		return
+	}
+	ship.remaining_cost -= amount
+	if ship.remaining_cost > 0 {
+		sp.report.printf("Spent %d on construction of %s. %d remains to be paid.\n", amount, ship.ship_name(), ship.remaining_cost)
+		return
+	}
+	ship.status = IN_ORBIT
+	sp.report.printf("Completed construction of %s at a cost of %d.\n", ship.ship_name(), amount)
+}
+
+// LoadShipClasses stores the ship class catalog in the database.
+func LoadShipClasses(ctx context.Context, q *sqlite3.Queries) error {
+	for _, sc := range ship_classes {
+		err := q.UpsertShipClass(ctx, sqlite3.UpsertShipClassParams{
+			ID:              int64(sc.class),
+			Abbr:            sc.abbr,
+			Name:            sc.name,
+			Tonnage:         int64(sc.tonnage),
+			Cost:            int64(sc.cost),
+			VariableTonnage: boolToInt64(sc.variable_tonnage),
+			SubLightOnly:    boolToInt64(sc.sub_light_only),
+			NeedsShipyard:   boolToInt64(sc.needs_shipyard),
+			CargoFactor:     int64(sc.cargo_factor),
+			CargoGrowth:     int64(sc.cargo_growth),
+			Tech:            tech_abbr[sc.tech],
+			MinLevel:        int64(sc.min_level),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+)
+
+// InTx calls fn with queries that run in a new transaction. The transaction
+// is committed if fn returns nil and rolled back otherwise. If the queries
+// are already in a transaction, fn runs in it.
+func (q *Queries) InTx(ctx context.Context, fn func(q *Queries) error) error {
+	db, ok := q.db.(*sql.DB)
+	if !ok {
+		return fn(q)
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(q.WithTx(tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
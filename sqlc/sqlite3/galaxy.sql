@@ -0,0 +1,42 @@
+--  Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+-- GetGalaxy returns the galaxy's size, current turn and random number generator state.
+--
+-- name: GetGalaxy :one
+SELECT num_species, radius, turn_number, prng_seed
+FROM galaxy_data;
+
+-- UpdateGalaxy stores the current turn and the state of the random number generator.
+--
+-- name: UpdateGalaxy :exec
+UPDATE galaxy_data
+SET turn_number = ?,
+    prng_seed   = ?;
+
+-- ListTurnPhases returns the phases of a turn that have been run.
+--
+-- name: ListTurnPhases :many
+SELECT phase
+FROM turn_phases
+WHERE turn_number = ?
+ORDER BY phase;
+
+-- CreateTurnPhase records that a phase of a turn has been run.
+--
+-- name: CreateTurnPhase :exec
+INSERT INTO turn_phases (turn_number, phase)
+VALUES (?, ?);
+
+-- ListStarVisits returns the star systems visited by every species.
+--
+-- name: ListStarVisits :many
+SELECT star_id, species_id, turn_number
+FROM star_visited_by
+ORDER BY star_id, species_id;
+
+-- CreateStarVisit records a species' visit to a star system, unless it has been recorded already.
+--
+-- name: CreateStarVisit :exec
+INSERT INTO star_visited_by (star_id, species_id, turn_number)
+VALUES (?, ?, ?)
+ON CONFLICT (star_id, species_id) DO NOTHING;
@@ -0,0 +1,20 @@
+--  Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+-- UpsertItem creates or updates an entry in the item catalog.
+--
+-- name: UpsertItem :exec
+INSERT INTO item_data (id, abbr, name, cost, carry, tech, min_level)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET abbr      = excluded.abbr,
+                               name      = excluded.name,
+                               cost      = excluded.cost,
+                               carry     = excluded.carry,
+                               tech      = excluded.tech,
+                               min_level = excluded.min_level;
+
+-- ListItems returns the item catalog.
+--
+-- name: ListItems :many
+SELECT id, abbr, name, cost, carry, tech, min_level
+FROM item_data
+ORDER BY id;
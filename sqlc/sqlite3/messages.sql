@@ -0,0 +1,22 @@
+--  Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+-- CreateMessage stores a message sent from one species to another.
+--
+-- name: CreateMessage :exec
+INSERT INTO message_data (turn_number, sender_id, recipient_id, message)
+VALUES (?, ?, ?, ?);
+
+-- DeleteMessages removes the messages sent during a turn.
+--
+-- name: DeleteMessages :exec
+DELETE
+FROM message_data
+WHERE turn_number = ?;
+
+-- ListMessages returns the messages sent during a turn, in the order they were sent.
+--
+-- name: ListMessages :many
+SELECT id, turn_number, sender_id, recipient_id, message
+FROM message_data
+WHERE turn_number = ?
+ORDER BY id;
@@ -16,3 +16,193 @@ type GameState struct {
 	NumPlanets   int64
 	PrngSeed     int64
 }
+
+type SpeciesTechAudit struct {
+	TurnNumber int64
+	SpeciesID  int64
+	Tech       string
+	OldLevel   int64
+	NewLevel   int64
+	Cost       int64
+	Reason     string
+}
+
+type ShipClassData struct {
+	ID              int64
+	Abbr            string
+	Name            string
+	Tonnage         int64
+	Cost            int64
+	VariableTonnage int64
+	SubLightOnly    int64
+	NeedsShipyard   int64
+	CargoFactor     int64
+	CargoGrowth     int64
+	Tech            string
+	MinLevel        int64
+}
+
+type ItemData struct {
+	ID       int64
+	Abbr     string
+	Name     string
+	Cost     int64
+	Carry    int64
+	Tech     string
+	MinLevel int64
+}
+
+type TransactionData struct {
+	TurnNumber  int64
+	Seq         int64
+	Type        string
+	DonorID     int64
+	RecipientID int64
+	Value       int64
+	X           int64
+	Y           int64
+	Z           int64
+	Pn          int64
+	Number1     int64
+	Name1       string
+	Number2     int64
+	Name2       string
+	Number3     int64
+	Name3       string
+}
+
+type SpeciesContact struct {
+	SpeciesID int64
+	AlienID   int64
+	Contact   int64
+	Ally      int64
+	Enemy     int64
+}
+
+type SpeciesRelation struct {
+	TurnNumber int64
+	SpeciesID  int64
+	AlienID    int64
+	Contact    int64
+	Ally       int64
+	Enemy      int64
+}
+
+type MessageData struct {
+	ID          int64
+	TurnNumber  int64
+	SenderID    int64
+	RecipientID int64
+	Message     string
+}
+
+type PlanetAtmosphereData struct {
+	PlanetID int64
+	GasID    int64
+	Percent  int64
+}
+
+type PlanetHistory struct {
+	TurnNumber int64
+	PlanetID   int64
+	SpeciesID  int64
+	Attribute  string
+	OldValue   string
+	NewValue   string
+	Reason     string
+}
+
+type PopulationHistory struct {
+	TurnNumber int64
+	SpeciesID  int64
+	NamplaID   int64
+	Name       string
+	PopUnits   int64
+	Growth     int64
+	Recruited  int64
+}
+
+type SpeciesOrder struct {
+	TurnNumber int64
+	SpeciesID  int64
+	Auto       int64
+	Orders     string
+}
+
+type GalaxyData struct {
+	NumSpecies int64
+	Radius     int64
+	TurnNumber int64
+	PrngSeed   int64
+}
+
+type StarVisitedBy struct {
+	StarID     int64
+	SpeciesID  int64
+	TurnNumber int64
+}
+
+type SpeciesAtmosphericGase struct {
+	SpeciesID     int64
+	GasID         int64
+	Poison        int64
+	Required      int64
+	MinPercentage int64
+	MaxPercentage int64
+}
+
+type SpeciesData struct {
+	ID               int64
+	Name             string
+	AutoOrders       int64
+	EconUnits        int64
+	FleetCost        int64
+	FleetPercentCost int64
+	GovtName         string
+	GovtType         string
+}
+
+type SpeciesHomePlanet struct {
+	SpeciesID      int64
+	PlanetID       int64
+	HpOriginalBase int64
+}
+
+type NamplaData struct {
+	SpeciesID    int64
+	ID           int64
+	PlanetID     int64
+	Name         string
+	AUsNeeded    int64
+	AUsToInstall int64
+	IUsNeeded    int64
+	IUsToInstall int64
+	AutoAUs      int64
+	AutoIUs      int64
+	Hidden       int64
+	Hiding       int64
+	MaBase       int64
+	Message      int64
+	MiBase       int64
+	PopUnits     int64
+	Recruited    int64
+	Shipyards    int64
+	SiegeEff     int64
+	Special      int64
+	Status       int64
+	UseOnAmbush  int64
+}
+
+type NamplaInventory struct {
+	SpeciesID int64
+	NamplaID  int64
+	ItemID    int64
+	Quantity  int64
+}
+
+type ShipInventory struct {
+	SpeciesID int64
+	ShipID    int64
+	ItemID    int64
+	Quantity  int64
+}
@@ -0,0 +1,47 @@
+--  Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+-- ListNamplas returns the named planets of every species.
+--
+-- name: ListNamplas :many
+SELECT species_id, id, planet_id, name,
+       AUs_needed, AUs_to_install, IUs_needed, IUs_to_install, auto_AUs, auto_IUs,
+       hidden, hiding, ma_base, message, mi_base, pop_units, recruited,
+       shipyards, siege_eff, special, status, use_on_ambush
+FROM nampla_data
+ORDER BY species_id, id;
+
+-- DeleteSpeciesNamplas removes the named planets of a species.
+--
+-- name: DeleteSpeciesNamplas :exec
+DELETE
+FROM nampla_data
+WHERE species_id = ?;
+
+-- CreateNampla stores a named planet.
+--
+-- name: CreateNampla :exec
+INSERT INTO nampla_data (species_id, id, planet_id, name,
+                         AUs_needed, AUs_to_install, IUs_needed, IUs_to_install, auto_AUs, auto_IUs,
+                         hidden, hiding, ma_base, message, mi_base, pop_units, recruited,
+                         shipyards, siege_eff, special, status, use_on_ambush)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- ListNamplaInventory returns the items stored on every named planet.
+--
+-- name: ListNamplaInventory :many
+SELECT species_id, nampla_id, item_id, quantity
+FROM nampla_inventory
+ORDER BY species_id, nampla_id, item_id;
+
+-- DeleteSpeciesNamplaInventory removes the items stored on the named planets of a species.
+--
+-- name: DeleteSpeciesNamplaInventory :exec
+DELETE
+FROM nampla_inventory
+WHERE species_id = ?;
+
+-- CreateNamplaInventory stores the quantity of an item on a named planet.
+--
+-- name: CreateNamplaInventory :exec
+INSERT INTO nampla_inventory (species_id, nampla_id, item_id, quantity)
+VALUES (?, ?, ?, ?);
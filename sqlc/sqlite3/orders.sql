@@ -0,0 +1,17 @@
+--  Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+-- UpsertSpeciesOrders stores the orders for a species for a turn, replacing any stored earlier.
+--
+-- name: UpsertSpeciesOrders :exec
+INSERT INTO species_orders (turn_number, species_id, auto, orders)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (turn_number, species_id) DO UPDATE SET auto   = excluded.auto,
+                                                    orders = excluded.orders;
+
+-- GetSpeciesOrders returns the orders stored for a species for a turn.
+--
+-- name: GetSpeciesOrders :one
+SELECT turn_number, species_id, auto, orders
+FROM species_orders
+WHERE turn_number = ?
+  AND species_id = ?;
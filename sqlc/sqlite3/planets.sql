@@ -0,0 +1,53 @@
+--  Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+-- UpdatePlanet stores the attributes of a planet that can change during the game.
+--
+-- name: UpdatePlanet :exec
+UPDATE planet_data
+SET temperature_class = ?,
+    pressure_class    = ?,
+    mining_difficulty = ?,
+    md_increase       = ?
+WHERE id = ?;
+
+-- DeletePlanetAtmosphere removes the gases in a planet's atmosphere.
+--
+-- name: DeletePlanetAtmosphere :exec
+DELETE
+FROM planet_atmosphere_data
+WHERE planet_id = ?;
+
+-- CreatePlanetAtmosphere adds a gas to a planet's atmosphere.
+--
+-- name: CreatePlanetAtmosphere :exec
+INSERT INTO planet_atmosphere_data (planet_id, gas_id, percent)
+VALUES (?, ?, ?);
+
+-- CreatePlanetHistory records a change made to a planet.
+--
+-- name: CreatePlanetHistory :exec
+INSERT INTO planet_history (turn_number, planet_id, species_id, attribute, old_value, new_value, reason)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- DeletePlanetHistory removes the changes recorded during a turn.
+--
+-- name: DeletePlanetHistory :exec
+DELETE
+FROM planet_history
+WHERE turn_number = ?;
+
+-- ListPlanetHistory returns the changes made to a planet, oldest first.
+--
+-- name: ListPlanetHistory :many
+SELECT turn_number, planet_id, species_id, attribute, old_value, new_value, reason
+FROM planet_history
+WHERE planet_id = ?
+ORDER BY turn_number, rowid;
+
+-- ListPlanetHistoryForTurn returns the changes made to planets during a turn, oldest first.
+--
+-- name: ListPlanetHistoryForTurn :many
+SELECT turn_number, planet_id, species_id, attribute, old_value, new_value, reason
+FROM planet_history
+WHERE turn_number = ?
+ORDER BY rowid;
@@ -0,0 +1,21 @@
+--  Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+-- CreatePopulationHistory records the population of a colony at the end of a turn.
+--
+-- name: CreatePopulationHistory :exec
+INSERT INTO population_history (turn_number, species_id, nampla_id, name, pop_units, growth, recruited)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- DeletePopulationHistory removes the populations recorded during a turn.
+--
+-- name: DeletePopulationHistory :exec
+DELETE
+FROM population_history
+WHERE turn_number = ?;
+
+-- ListPopulationHistory returns the population of every colony, grouped by colony, oldest first.
+--
+-- name: ListPopulationHistory :many
+SELECT turn_number, species_id, nampla_id, name, pop_units, growth, recruited
+FROM population_history
+ORDER BY species_id, nampla_id, turn_number;
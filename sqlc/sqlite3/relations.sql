@@ -0,0 +1,47 @@
+--  Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+-- UpsertSpeciesContact creates or updates a species' current relations with another species.
+--
+-- name: UpsertSpeciesContact :exec
+INSERT INTO species_contacts (species_id, alien_id, contact, ally, enemy)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (species_id, alien_id) DO UPDATE SET contact = excluded.contact,
+                                                 ally    = excluded.ally,
+                                                 enemy   = excluded.enemy;
+
+-- ListSpeciesContacts returns a species' current relations with the other species.
+--
+-- name: ListSpeciesContacts :many
+SELECT species_id, alien_id, contact, ally, enemy
+FROM species_contacts
+WHERE species_id = ?
+ORDER BY alien_id;
+
+-- CreateSpeciesRelation records a species' relations with another species at the end of a turn.
+--
+-- name: CreateSpeciesRelation :exec
+INSERT INTO species_relations (turn_number, species_id, alien_id, contact, ally, enemy)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- DeleteSpeciesRelations removes the relations recorded for a turn.
+--
+-- name: DeleteSpeciesRelations :exec
+DELETE
+FROM species_relations
+WHERE turn_number = ?;
+
+-- ListSpeciesRelations returns the relations of every species recorded for a turn.
+--
+-- name: ListSpeciesRelations :many
+SELECT turn_number, species_id, alien_id, contact, ally, enemy
+FROM species_relations
+WHERE turn_number = ?
+ORDER BY species_id, alien_id;
+
+-- GetLatestRelationsTurn returns the last turn that relations were recorded for.
+--
+-- name: GetLatestRelationsTurn :one
+SELECT turn_number
+FROM species_relations
+ORDER BY turn_number DESC
+LIMIT 1;
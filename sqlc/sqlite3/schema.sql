@@ -101,47 +101,76 @@ CREATE TABLE galaxy_data
     prng_seed   INTEGER NOT NULL
 );
 
--- message_data stores message data.
+-- turn_phases records the phases of each turn that have been run.
+-- A phase can only be run once a turn.
+CREATE TABLE turn_phases
+(
+    turn_number INTEGER NOT NULL,
+    phase       TEXT    NOT NULL, -- name of the phase, e.g. PRODUCTION or FINISH
+    PRIMARY KEY (turn_number, phase)
+);
+
+-- item_data stores the item catalog for the game, including any variant rules.
+CREATE TABLE item_data
+(
+    id        INTEGER PRIMARY KEY,     -- item_e value
+    abbr      TEXT    NOT NULL UNIQUE, -- item abbreviation, e.g. CU
+    name      TEXT    NOT NULL,        -- item name, e.g. Colonist Unit
+    cost      INTEGER NOT NULL,        -- cost in EUs of one unit. Zero if the item can't be built
+    carry     INTEGER NOT NULL,        -- carrying capacity used by one unit
+    tech      TEXT    NOT NULL,        -- critical tech needed to build the item
+    min_level INTEGER NOT NULL         -- minimum level of the critical tech
+);
+
+-- message_data stores the messages sent between species by MESSAGE orders.
 CREATE TABLE message_data
 (
-    id      INTEGER PRIMARY KEY,
-    message TEXT NOT NULL
+    id           INTEGER PRIMARY KEY,
+    turn_number  INTEGER NOT NULL, -- turn the message was sent in
+    sender_id    INTEGER NOT NULL,
+    recipient_id INTEGER NOT NULL,
+    message      TEXT    NOT NULL
 );
 
--- need to understand where nampla.status is used.
+-- nampla_data stores nampla_data_t.
 -- item_quantity moved to nampla_inventory table.
+-- id is the index of the named planet within the species, starting at 1.
 CREATE TABLE nampla_data
 (
-    id             INTEGER PRIMARY KEY,        -- unique identifier for this system
+    species_id     INTEGER NOT NULL,           -- species that named the planet
+    id             INTEGER NOT NULL,           -- identifier of the named planet within the species
     planet_id      INTEGER NOT NULL,           -- pointer to planet the colony is on
     name           TEXT    NOT NULL,           -- Name of planet
-    AUs_needed     INTEGER,                    -- Incoming ship with only CUs on board
-    AUs_to_install INTEGER,                    -- Colonial manufacturing units to be installed
-    IUs_needed     INTEGER,                    -- Incoming ship with only CUs on board
-    IUs_to_install INTEGER,                    -- Colonial mining units to be installed
-    auto_AUs       INTEGER,                    -- Number of AUs to be automatically installed
-    auto_IUs       INTEGER,                    -- Number of IUs to be automatically installed
+    AUs_needed     INTEGER NOT NULL DEFAULT 0, -- Incoming ship with only CUs on board
+    AUs_to_install INTEGER NOT NULL DEFAULT 0, -- Colonial manufacturing units to be installed
+    IUs_needed     INTEGER NOT NULL DEFAULT 0, -- Incoming ship with only CUs on board
+    IUs_to_install INTEGER NOT NULL DEFAULT 0, -- Colonial mining units to be installed
+    auto_AUs       INTEGER NOT NULL DEFAULT 0, -- Number of AUs to be automatically installed
+    auto_IUs       INTEGER NOT NULL DEFAULT 0, -- Number of IUs to be automatically installed
     hidden         INTEGER NOT NULL DEFAULT 0, -- Colony is hidden
     hiding         INTEGER NOT NULL DEFAULT 0, -- HIDE order given
-    ma_base        INTEGER,                    -- Manufacturing base times 10
-    message        INTEGER,                    -- Message associated with this planet, if any
-    mi_base        INTEGER,                    -- Mining base times 10
-    pop_units      INTEGER,                    -- Number of available population units
-    shipyards      INTEGER,                    -- Number of shipyards on planet
-    siege_eff      INTEGER,                    -- Siege effectiveness - a percentage between 0 and 99
-    special        INTEGER,                    -- Different for each application
-    status         INTEGER,                    -- Status of planet
-    use_on_ambush  INTEGER                     -- Amount to use on ambush
+    ma_base        INTEGER NOT NULL DEFAULT 0, -- Manufacturing base times 10
+    message        INTEGER NOT NULL DEFAULT 0, -- Message associated with this planet, if any
+    mi_base        INTEGER NOT NULL DEFAULT 0, -- Mining base times 10
+    pop_units      INTEGER NOT NULL DEFAULT 0, -- Number of available population units
+    recruited      INTEGER NOT NULL DEFAULT 0, -- Population units recruited as CUs this turn
+    shipyards      INTEGER NOT NULL DEFAULT 0, -- Number of shipyards on planet
+    siege_eff      INTEGER NOT NULL DEFAULT 0, -- Siege effectiveness - a percentage between 0 and 99
+    special        INTEGER NOT NULL DEFAULT 0, -- Different for each application
+    status         INTEGER NOT NULL DEFAULT 0, -- Status of planet
+    use_on_ambush  INTEGER NOT NULL DEFAULT 0, -- Amount to use on ambush
+    PRIMARY KEY (species_id, id)
 );
 
 -- nampla_inventory stores inventory for a named planet (eg colony).
 -- 	item_quantity  [MAX_ITEMS]int  -- Quantity of each item available
 CREATE TABLE nampla_inventory
 (
-    nampla_id INTEGER NOT NULL,
-    item_id   INTEGER NOT NULL,
-    quantity  INTEGER NOT NULL,
-    PRIMARY KEY (nampla_id, item_id)
+    species_id INTEGER NOT NULL,
+    nampla_id  INTEGER NOT NULL,
+    item_id    INTEGER NOT NULL,
+    quantity   INTEGER NOT NULL,
+    PRIMARY KEY (species_id, nampla_id, item_id)
 );
 
 -- planet_data stores planet_data_t.
@@ -170,6 +199,33 @@ CREATE TABLE planet_atmosphere_data
     percent   INTEGER NOT NULL  -- Percentage of gas in atmosphere
 );
 
+-- planet_history records every change made to a planet after it was generated,
+-- so that reports can show how terraforming and mining have altered it.
+CREATE TABLE planet_history
+(
+    turn_number INTEGER NOT NULL,
+    planet_id   INTEGER NOT NULL,
+    species_id  INTEGER NOT NULL, -- species that caused the change
+    attribute   TEXT    NOT NULL, -- e.g. TEMPERATURE_CLASS or ATMOSPHERE
+    old_value   TEXT    NOT NULL,
+    new_value   TEXT    NOT NULL,
+    reason      TEXT    NOT NULL  -- TERRAFORM or MINING
+);
+
+-- population_history records the population of every colony at the end of
+-- each turn, so that the GM can audit how colonies grow.
+CREATE TABLE population_history
+(
+    turn_number INTEGER NOT NULL,
+    species_id  INTEGER NOT NULL,
+    nampla_id   INTEGER NOT NULL,
+    name        TEXT    NOT NULL, -- name of the colony
+    pop_units   INTEGER NOT NULL, -- available population units after growth
+    growth      INTEGER NOT NULL, -- population units added by growth during the turn
+    recruited   INTEGER NOT NULL, -- population units recruited as CUs during the turn
+    PRIMARY KEY (turn_number, species_id, nampla_id)
+);
+
 -- planet_inventory stores inventory for a planet.
 -- 	item_quantity  [MAX_ITEMS]int  -- Quantity of each item available
 CREATE TABLE planet_inventory
@@ -185,6 +241,7 @@ CREATE TABLE planet_inventory
 CREATE TABLE ship_data
 (
     id                   INTEGER PRIMARY KEY,        -- unique identifier for this system
+    species_id           INTEGER NOT NULL,           -- species that owns the ship
     name                 TEXT    NOT NULL,           -- Name of ship
     x                    INTEGER NOT NULL,           -- Coordinates
     y                    INTEGER NOT NULL,           -- Coordinates
@@ -192,6 +249,7 @@ CREATE TABLE ship_data
     age                  INTEGER NOT NULL,           -- Ship age
     arrived_via_wormhole INTEGER NOT NULL DEFAULT 0, -- Ship arrived via wormhole in the PREVIOUS turn
     class                INTEGER NOT NULL,           -- Ship class
+    damage               INTEGER NOT NULL DEFAULT 0, -- Years of the ship's age caused by battle damage that hasn't been repaired
     dest_x               INTEGER NOT NULL,           -- Destination if ship was forced to jump from combat. Also used by TELESCOPE command
     dest_y               INTEGER NOT NULL,
     dest_z               INTEGER NOT NULL,
@@ -199,7 +257,7 @@ CREATE TABLE ship_data
     loading_point        INTEGER NOT NULL,           -- Nampla index for planet where ship was last loaded with CUs. Zero = none. Use 9999 for home planet
     pn                   INTEGER NOT NULL,           -- Current coordinates
     remaining_cost       INTEGER NOT NULL,           -- The cost needed to complete the ship if still under construction
-    special              INTEGER,                    -- Different for each application
+    special              INTEGER NOT NULL DEFAULT 0, -- Different for each application
     status               INTEGER NOT NULL,           -- Current status of ship
     tonnage              INTEGER NOT NULL,           -- Ship tonnage divided by 10,000
     type_                INTEGER NOT NULL,           -- Ship type
@@ -210,12 +268,30 @@ CREATE TABLE ship_data
 -- 	item_quantity  [MAX_ITEMS]int  -- Quantity of each item available
 CREATE TABLE ship_inventory
 (
-    ship_id  INTEGER NOT NULL,
-    item_id  INTEGER NOT NULL,
-    quantity INTEGER NOT NULL,
+    species_id INTEGER NOT NULL, -- species that owns the ship
+    ship_id    INTEGER NOT NULL,
+    item_id    INTEGER NOT NULL,
+    quantity   INTEGER NOT NULL,
     PRIMARY KEY (ship_id, item_id)
 );
 
+-- ship_class_data stores the ship class catalog.
+CREATE TABLE ship_class_data
+(
+    id               INTEGER PRIMARY KEY,        -- ship_class_e value
+    abbr             TEXT    NOT NULL UNIQUE,    -- class abbreviation, e.g. DD
+    name             TEXT    NOT NULL,           -- class name, e.g. Destroyer
+    tonnage          INTEGER NOT NULL,           -- tonnage divided by 10,000. Minimum tonnage for classes built to order
+    cost             INTEGER NOT NULL,           -- cost in EUs of an FTL ship. Cost per unit of tonnage for classes built to order
+    variable_tonnage INTEGER NOT NULL DEFAULT 0, -- set if the tonnage is given when the ship is built
+    sub_light_only   INTEGER NOT NULL DEFAULT 0, -- set if the class can't be built with FTL drives
+    needs_shipyard   INTEGER NOT NULL DEFAULT 0, -- set if the class must be built in a shipyard
+    cargo_factor     INTEGER NOT NULL,           -- carrying capacity per unit of tonnage
+    cargo_growth     INTEGER NOT NULL,           -- capacity per unit of tonnage grows by one for every this many units of tonnage
+    tech             TEXT    NOT NULL,           -- tech needed to build the class
+    min_level        INTEGER NOT NULL            -- minimum tech level per unit of tonnage
+);
+
 CREATE TABLE species_cfg
 (
     email          TEXT    NOT NULL,
@@ -233,6 +309,7 @@ CREATE TABLE species_cfg
 CREATE TABLE species_data
 (
     id                 INTEGER PRIMARY KEY,
+    name               TEXT    NOT NULL,           -- Name of species
     auto_orders        INTEGER NOT NULL DEFAULT 0, -- AUTO command was issued
     econ_units         INTEGER NOT NULL,           -- Number of economic units
     fleet_cost         INTEGER NOT NULL,           -- Total fleet maintenance cost
@@ -247,8 +324,8 @@ CREATE TABLE species_atmospheric_gases
     gas_id         INTEGER NOT NULL,
     poison         INTEGER NOT NULL DEFAULT 0,
     required       INTEGER NOT NULL DEFAULT 0,
-    min_percentage INTEGER, -- minimum needed percentage, set only for required gases
-    max_percentage INTEGER  -- maximum allowed percentage, set only for required gases
+    min_percentage INTEGER NOT NULL DEFAULT 0, -- minimum needed percentage, set only for required gases
+    max_percentage INTEGER NOT NULL DEFAULT 0  -- maximum allowed percentage, set only for required gases
 );
 
 CREATE TABLE species_contacts
@@ -265,10 +342,45 @@ CREATE TABLE species_home_planet
 (
     species_id       INTEGER NOT NULL,
     planet_id        INTEGER NOT NULL,
-    hp_original_base INTEGER, -- If non-zero, home planet was bombed either by bombardment or germ warfare and has not yet fully recovered. Value is total economic base before bombing.
+    hp_original_base INTEGER NOT NULL DEFAULT 0, -- If non-zero, home planet was bombed either by bombardment or germ warfare and has not yet fully recovered. Value is total economic base before bombing.
     PRIMARY KEY (species_id)
 );
 
+-- species_orders stores the orders submitted by each species for a turn.
+-- Orders generated for a species that gave the AUTO command are stored here
+-- as well, and are replaced if the player submits orders of their own.
+CREATE TABLE species_orders
+(
+    turn_number INTEGER NOT NULL,
+    species_id  INTEGER NOT NULL,
+    auto        INTEGER NOT NULL DEFAULT 0, -- orders were generated by the AUTO command
+    orders      TEXT    NOT NULL,           -- text of the orders file
+    PRIMARY KEY (turn_number, species_id)
+);
+
+-- species_reports stores the status report of each species for a turn.
+-- Every phase of the turn adds to the report.
+CREATE TABLE species_reports
+(
+    turn_number INTEGER NOT NULL,
+    species_id  INTEGER NOT NULL,
+    report      TEXT    NOT NULL,
+    PRIMARY KEY (turn_number, species_id)
+);
+
+-- species_relations records each species' relations with the other species at the end of every turn.
+-- species_contacts holds the current relations; this table keeps the history.
+CREATE TABLE species_relations
+(
+    turn_number INTEGER NOT NULL,
+    species_id  INTEGER NOT NULL,
+    alien_id    INTEGER NOT NULL,
+    contact     INTEGER NOT NULL DEFAULT 0,
+    ally        INTEGER NOT NULL DEFAULT 0,
+    enemy       INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (turn_number, species_id, alien_id)
+);
+
 CREATE TABLE species_tech_levels
 (
     species_id   INTEGER NOT NULL,
@@ -289,7 +401,20 @@ CREATE TABLE species_tech_levels
     mi_unapplied INTEGER NOT NULL DEFAULT 0, -- un-applied Mining tech level
     ml           INTEGER NOT NULL DEFAULT 0, -- Military tech level
     ml_exp       INTEGER NOT NULL DEFAULT 0, -- experience points for Military tech level
-    ml_unapplied INTEGER NOT NULL DEFAULT 0  -- un-applied Military tech level
+    ml_unapplied INTEGER NOT NULL DEFAULT 0, -- un-applied Military tech level
+    PRIMARY KEY (species_id)
+);
+
+-- species_tech_audit records every change to a species' tech levels so that reports can explain why a level moved.
+CREATE TABLE species_tech_audit
+(
+    turn_number INTEGER NOT NULL,
+    species_id  INTEGER NOT NULL,
+    tech        TEXT    NOT NULL, -- tech level abbreviation, e.g. MI or GV
+    old_level   INTEGER NOT NULL,
+    new_level   INTEGER NOT NULL,
+    cost        INTEGER NOT NULL, -- EUs or experience points consumed by the change
+    reason      TEXT    NOT NULL
 );
 
 -- star_data stores star_data_t.
@@ -319,10 +444,34 @@ CREATE TABLE star_visited_by
 (
     star_id     INTEGER NOT NULL REFERENCES star_data (id),
     species_id  INTEGER NOT NULL REFERENCES species_data (id),
-    turn_number INTEGER NOT NULL -- last turn visited by this species
+    turn_number INTEGER NOT NULL, -- turn the visit was first recorded
+    PRIMARY KEY (star_id, species_id)
 );
 --planets [10]*planet_data_t                 -- planets in this star system
 
+-- transaction_data is the ledger of interspecies transactions (trans_data_t).
+-- Rows are kept for every turn; seq is the order the transactions were made in.
+CREATE TABLE transaction_data
+(
+    turn_number  INTEGER NOT NULL,
+    seq          INTEGER NOT NULL,
+    type         TEXT    NOT NULL, -- transaction type, e.g. EU_TRANSFER
+    donor_id     INTEGER NOT NULL,
+    recipient_id INTEGER NOT NULL,
+    value        INTEGER NOT NULL, -- value of transaction
+    x            INTEGER NOT NULL, -- location associated with transaction
+    y            INTEGER NOT NULL,
+    z            INTEGER NOT NULL,
+    pn           INTEGER NOT NULL,
+    number1      INTEGER NOT NULL, -- other items associated with transaction
+    name1        TEXT    NOT NULL,
+    number2      INTEGER NOT NULL,
+    name2        TEXT    NOT NULL,
+    number3      INTEGER NOT NULL,
+    name3        TEXT    NOT NULL,
+    PRIMARY KEY (turn_number, seq)
+);
+
 CREATE TABLE wormhole_data
 (
     from_star_x INTEGER NOT NULL,
@@ -0,0 +1,70 @@
+--  Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+-- UpsertShipClass creates or updates an entry in the ship class catalog.
+--
+-- name: UpsertShipClass :exec
+INSERT INTO ship_class_data (id, abbr, name, tonnage, cost, variable_tonnage, sub_light_only, needs_shipyard,
+                             cargo_factor, cargo_growth, tech, min_level)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET abbr             = excluded.abbr,
+                               name             = excluded.name,
+                               tonnage          = excluded.tonnage,
+                               cost             = excluded.cost,
+                               variable_tonnage = excluded.variable_tonnage,
+                               sub_light_only   = excluded.sub_light_only,
+                               needs_shipyard   = excluded.needs_shipyard,
+                               cargo_factor     = excluded.cargo_factor,
+                               cargo_growth     = excluded.cargo_growth,
+                               tech             = excluded.tech,
+                               min_level        = excluded.min_level;
+
+-- ListShipClasses returns the ship class catalog.
+--
+-- name: ListShipClasses :many
+SELECT id, abbr, name, tonnage, cost, variable_tonnage, sub_light_only, needs_shipyard, cargo_factor, cargo_growth, tech, min_level
+FROM ship_class_data
+ORDER BY id;
+
+-- ListShips returns the ships of every species.
+--
+-- name: ListShips :many
+SELECT id, species_id, name, x, y, z, pn, age, arrived_via_wormhole, class, damage,
+       dest_x, dest_y, dest_z, just_jumped, loading_point, remaining_cost, special,
+       status, tonnage, type_, unloading_point
+FROM ship_data
+ORDER BY species_id, id;
+
+-- DeleteSpeciesShips removes the ships of a species.
+--
+-- name: DeleteSpeciesShips :exec
+DELETE
+FROM ship_data
+WHERE species_id = ?;
+
+-- CreateShip stores a ship.
+--
+-- name: CreateShip :exec
+INSERT INTO ship_data (id, species_id, name, x, y, z, pn, age, arrived_via_wormhole, class, damage,
+                       dest_x, dest_y, dest_z, just_jumped, loading_point, remaining_cost, special,
+                       status, tonnage, type_, unloading_point)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- ListShipInventory returns the items carried by every ship.
+--
+-- name: ListShipInventory :many
+SELECT species_id, ship_id, item_id, quantity
+FROM ship_inventory
+ORDER BY species_id, ship_id, item_id;
+
+-- DeleteSpeciesShipInventory removes the items carried by the ships of a species.
+--
+-- name: DeleteSpeciesShipInventory :exec
+DELETE
+FROM ship_inventory
+WHERE species_id = ?;
+
+-- CreateShipInventory stores the quantity of an item carried by a ship.
+--
+-- name: CreateShipInventory :exec
+INSERT INTO ship_inventory (species_id, ship_id, item_id, quantity)
+VALUES (?, ?, ?, ?);
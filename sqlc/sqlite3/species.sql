@@ -0,0 +1,67 @@
+--  Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+-- ListSpecies returns every species in the game.
+--
+-- name: ListSpecies :many
+SELECT id, name, auto_orders, econ_units, fleet_cost, fleet_percent_cost, govt_name, govt_type
+FROM species_data
+ORDER BY id;
+
+-- UpdateSpecies stores the attributes of a species that can change during a turn.
+--
+-- name: UpdateSpecies :exec
+UPDATE species_data
+SET auto_orders        = ?,
+    econ_units         = ?,
+    fleet_cost         = ?,
+    fleet_percent_cost = ?
+WHERE id = ?;
+
+-- ListSpeciesHomePlanets returns the home planet of every species.
+--
+-- name: ListSpeciesHomePlanets :many
+SELECT species_id, planet_id, hp_original_base
+FROM species_home_planet
+ORDER BY species_id;
+
+-- UpdateSpeciesHomePlanet stores the economic base of a species' home planet before it was bombed.
+--
+-- name: UpdateSpeciesHomePlanet :exec
+UPDATE species_home_planet
+SET hp_original_base = ?
+WHERE species_id = ?;
+
+-- ListSpeciesAtmosphericGases returns the gases that every species needs or is poisoned by.
+--
+-- name: ListSpeciesAtmosphericGases :many
+SELECT species_id, gas_id, poison, required, min_percentage, max_percentage
+FROM species_atmospheric_gases
+ORDER BY species_id, rowid;
+
+-- ListSpeciesTechLevels returns the tech levels of every species.
+--
+-- name: ListSpeciesTechLevels :many
+SELECT species_id,
+       mi, mi_exp, mi_unapplied,
+       ma, ma_exp, ma_unapplied,
+       ml, ml_exp, ml_unapplied,
+       gv, gv_exp, gv_unapplied,
+       ls, ls_exp, ls_unapplied,
+       bi, bi_exp, bi_unapplied
+FROM species_tech_levels
+ORDER BY species_id;
+
+-- GetSpeciesReport returns the report of a species for a turn.
+--
+-- name: GetSpeciesReport :one
+SELECT report
+FROM species_reports
+WHERE turn_number = ?
+  AND species_id = ?;
+
+-- UpsertSpeciesReport stores the report of a species for a turn, replacing any stored earlier.
+--
+-- name: UpsertSpeciesReport :exec
+INSERT INTO species_reports (turn_number, species_id, report)
+VALUES (?, ?, ?)
+ON CONFLICT (turn_number, species_id) DO UPDATE SET report = excluded.report;
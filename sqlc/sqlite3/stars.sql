@@ -0,0 +1,22 @@
+--  Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+-- ListStars returns every star system in the galaxy.
+--
+-- name: ListStars :many
+SELECT id, x, y, z, color, size, type_
+FROM star_data
+ORDER BY id;
+
+-- ListPlanets returns every planet in the galaxy, in orbital order within each star system.
+--
+-- name: ListPlanets :many
+SELECT id, star_id, pn, diameter, econ_efficiency, gravity, md_increase, mining_difficulty, pressure_class, temperature_class
+FROM planet_data
+ORDER BY star_id, pn;
+
+-- ListPlanetAtmospheres returns the atmospheric gases of every planet in the galaxy.
+--
+-- name: ListPlanetAtmospheres :many
+SELECT planet_id, gas_id, percent
+FROM planet_atmosphere_data
+ORDER BY planet_id, rowid;
@@ -0,0 +1,54 @@
+--  Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+-- UpsertSpeciesTechLevels creates or updates the tech levels for a species.
+--
+-- name: UpsertSpeciesTechLevels :exec
+INSERT INTO species_tech_levels (species_id,
+                                 mi, mi_exp, mi_unapplied,
+                                 ma, ma_exp, ma_unapplied,
+                                 ml, ml_exp, ml_unapplied,
+                                 gv, gv_exp, gv_unapplied,
+                                 ls, ls_exp, ls_unapplied,
+                                 bi, bi_exp, bi_unapplied)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (species_id) DO UPDATE SET mi           = excluded.mi,
+                                       mi_exp       = excluded.mi_exp,
+                                       mi_unapplied = excluded.mi_unapplied,
+                                       ma           = excluded.ma,
+                                       ma_exp       = excluded.ma_exp,
+                                       ma_unapplied = excluded.ma_unapplied,
+                                       ml           = excluded.ml,
+                                       ml_exp       = excluded.ml_exp,
+                                       ml_unapplied = excluded.ml_unapplied,
+                                       gv           = excluded.gv,
+                                       gv_exp       = excluded.gv_exp,
+                                       gv_unapplied = excluded.gv_unapplied,
+                                       ls           = excluded.ls,
+                                       ls_exp       = excluded.ls_exp,
+                                       ls_unapplied = excluded.ls_unapplied,
+                                       bi           = excluded.bi,
+                                       bi_exp       = excluded.bi_exp,
+                                       bi_unapplied = excluded.bi_unapplied;
+
+-- CreateSpeciesTechAudit records a change to a species' tech level.
+--
+-- name: CreateSpeciesTechAudit :exec
+INSERT INTO species_tech_audit (turn_number, species_id, tech, old_level, new_level, cost, reason)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- ListSpeciesTechAudit returns the changes to a species' tech levels for a turn.
+--
+-- name: ListSpeciesTechAudit :many
+SELECT turn_number, species_id, tech, old_level, new_level, cost, reason
+FROM species_tech_audit
+WHERE species_id = ?
+  AND turn_number = ?
+ORDER BY rowid;
+
+-- DeleteSpeciesTechAudit removes the changes to a species' tech levels for a turn.
+--
+-- name: DeleteSpeciesTechAudit :exec
+DELETE
+FROM species_tech_audit
+WHERE species_id = ?
+  AND turn_number = ?;
@@ -0,0 +1,19 @@
+--  Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+-- CreateTransaction adds an interspecies transaction to the ledger.
+--
+-- name: CreateTransaction :exec
+INSERT INTO transaction_data (turn_number, seq, type, donor_id, recipient_id, value,
+                              x, y, z, pn,
+                              number1, name1, number2, name2, number3, name3)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- ListTransactions returns the transactions for a turn, in the order they were made.
+--
+-- name: ListTransactions :many
+SELECT turn_number, seq, type, donor_id, recipient_id, value,
+       x, y, z, pn,
+       number1, name1, number2, name2, number3, name3
+FROM transaction_data
+WHERE turn_number = ?
+ORDER BY seq;
@@ -0,0 +1,214 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"context"
+	"github.com/playbymail/fhgo/sqlc/sqlite3"
+)
+
+var tech_abbr = [6]string{"MI", "MA", "ML", "GV", "LS", "BI"}
+
+var tech_name = [6]string{"Mining", "Manufacturing", "Military", "Gravitics", "Life Support", "Biology"}
+
+func (t tech_level_e) String() string {
+	return tech_name[t]
+}
+
+// Reasons for a change in tech level
+type tech_audit_reason_e int
+
+const (
+	TECH_RESEARCH     tech_audit_reason_e = iota // EUs spent on research with knowledge of the level
+	TECH_EXPERIENCE                              // experience points converted at the end of the turn
+	TECH_BREAKTHROUGH                            // random advance from leftover experience points
+)
+
+func (r tech_audit_reason_e) String() string {
+	switch r {
+	case TECH_RESEARCH:
+		return "research using knowledge"
+	case TECH_EXPERIENCE:
+		return "experience"
+	case TECH_BREAKTHROUGH:
+		return "breakthrough"
+	}
+	return "unknown"
+}
+
+// tech_audit_reason_from_name returns the reason whose String is the name.
+func tech_audit_reason_from_name(name string) tech_audit_reason_e {
+	for _, r := range []tech_audit_reason_e{TECH_RESEARCH, TECH_EXPERIENCE, TECH_BREAKTHROUGH} {
+		if r.String() == name {
+			return r
+		}
+	}
+	return TECH_RESEARCH
+}
+
+// tech_audit_t records a change to a species' tech level so that the report can explain why it moved.
+type tech_audit_t struct {
+	turn_number int
+	tech        tech_level_e
+	old_level   int
+	new_level   int
+	cost        int // EUs or experience points consumed by the change
+	reason      tech_audit_reason_e
+}
+
+// tech_level_cost returns the cost, in experience points, to raise a tech level by one.
+// The cost is the square of the current level.
+func tech_level_cost(level int) int {
+	if level < 1 {
+		return 1
+	}
+	return level * level
+}
+
+// research spends EUs on a tech.
+//
+// Knowledge learned from other species is applied first. Each known level is
+// purchased immediately at a 25% discount. Whatever is left over is added to
+// the experience points for the tech and is applied at the end of the turn.
+func (sp *species_data_t) research(tech tech_level_e, amount, turn_number int) {
+	for sp.tech_level[tech] < sp.tech_knowledge[tech] {
+		cost := tech_level_cost(sp.tech_level[tech])
+		cost -= cost / 4
+		if cost > amount {
+			break
+		}
+		amount -= cost
+		sp.tech_level[tech]++
+		sp.tech_audit = append(sp.tech_audit, &tech_audit_t{
+			turn_number: turn_number,
+			tech:        tech,
+			old_level:   sp.tech_level[tech] - 1,
+			new_level:   sp.tech_level[tech],
+			cost:        cost,
+			reason:      TECH_RESEARCH,
+		})
+	}
+	sp.tech_eps[tech] += amount
+}
+
+// learn_tech records knowledge of a tech level received from another species.
+// Knowledge never decreases and has no effect until it is applied by research.
+func (sp *species_data_t) learn_tech(tech tech_level_e, level int) bool {
+	if level <= sp.tech_knowledge[tech] || level <= sp.tech_level[tech] {
+		return false
+	}
+	sp.tech_knowledge[tech] = level
+	return true
+}
+
+// update_tech_levels converts experience points into tech levels at the end of the turn.
+//
+// Experience points are spent on levels for as long as they cover the cost of the next level.
+// Any experience points left over give a chance of a breakthrough. The chance, as a percentage,
+// is the leftover points as a share of the cost of the next level. A breakthrough raises the
+// level by one and uses up the leftover points.
+func (sp *species_data_t) update_tech_levels(turn_number int) {
+	for tech := MI; tech <= BI; tech++ {
+		for cost := tech_level_cost(sp.tech_level[tech]); 0 < sp.tech_eps[tech] && cost <= sp.tech_eps[tech]; cost = tech_level_cost(sp.tech_level[tech]) {
+			sp.tech_eps[tech] -= cost
+			sp.tech_level[tech]++
+			sp.tech_audit = append(sp.tech_audit, &tech_audit_t{
+				turn_number: turn_number,
+				tech:        tech,
+				old_level:   sp.tech_level[tech] - 1,
+				new_level:   sp.tech_level[tech],
+				cost:        cost,
+				reason:      TECH_EXPERIENCE,
+			})
+		}
+		if sp.tech_eps[tech] > 0 {
+			chance := 100 * sp.tech_eps[tech] / tech_level_cost(sp.tech_level[tech])
+			if rnd(100) <= chance {
+				sp.tech_audit = append(sp.tech_audit, &tech_audit_t{
+					turn_number: turn_number,
+					tech:        tech,
+					old_level:   sp.tech_level[tech],
+					new_level:   sp.tech_level[tech] + 1,
+					cost:        sp.tech_eps[tech],
+					reason:      TECH_BREAKTHROUGH,
+				})
+				sp.tech_eps[tech] = 0
+				sp.tech_level[tech]++
+			}
+		}
+		// knowledge is used up once the species reaches the level
+		if sp.tech_knowledge[tech] <= sp.tech_level[tech] {
+			sp.tech_knowledge[tech] = 0
+		}
+	}
+
+	// the levels at the end of this turn are the starting levels for the next
+	sp.init_tech_level = sp.tech_level
+
+	sp.report_tech_levels(turn_number)
+}
+
+// report_tech_levels adds the tech level changes for the turn to the species' report.
+func (sp *species_data_t) report_tech_levels(turn_number int) {
+	header := false
+	for _, audit := range sp.tech_audit {
+		if audit.turn_number != turn_number {
+			continue
+		}
+		if !header {
+			sp.report.printf("\nTech level changes:\n")
+			header = true
+		}
+		sp.report.printf("  %-13s %3d -> %3d  (%s, cost %d)\n", audit.tech, audit.old_level, audit.new_level, audit.reason, audit.cost)
+	}
+}
+
+// save_tech_levels persists the species' tech levels and replaces the audit trail for the turn.
+func (sp *species_data_t) save_tech_levels(ctx context.Context, q *sqlite3.Queries, turn_number int) error {
+	err := q.UpsertSpeciesTechLevels(ctx, sqlite3.UpsertSpeciesTechLevelsParams{
+		SpeciesID:   int64(sp.id),
+		Mi:          int64(sp.tech_level[MI]),
+		MiExp:       int64(sp.tech_eps[MI]),
+		MiUnapplied: int64(sp.tech_knowledge[MI]),
+		Ma:          int64(sp.tech_level[MA]),
+		MaExp:       int64(sp.tech_eps[MA]),
+		MaUnapplied: int64(sp.tech_knowledge[MA]),
+		Ml:          int64(sp.tech_level[ML]),
+		MlExp:       int64(sp.tech_eps[ML]),
+		MlUnapplied: int64(sp.tech_knowledge[ML]),
+		Gv:          int64(sp.tech_level[GV]),
+		GvExp:       int64(sp.tech_eps[GV]),
+		GvUnapplied: int64(sp.tech_knowledge[GV]),
+		Ls:          int64(sp.tech_level[LS]),
+		LsExp:       int64(sp.tech_eps[LS]),
+		LsUnapplied: int64(sp.tech_knowledge[LS]),
+		Bi:          int64(sp.tech_level[BI]),
+		BiExp:       int64(sp.tech_eps[BI]),
+		BiUnapplied: int64(sp.tech_knowledge[BI]),
+	})
+	if err != nil {
+		return err
+	}
+	err = q.DeleteSpeciesTechAudit(ctx, sqlite3.DeleteSpeciesTechAuditParams{SpeciesID: int64(sp.id), TurnNumber: int64(turn_number)})
+	if err != nil {
+		return err
+	}
+	for _, audit := range sp.tech_audit {
+		if audit.turn_number != turn_number {
+			continue
+		}
+		err = q.CreateSpeciesTechAudit(ctx, sqlite3.CreateSpeciesTechAuditParams{
+			TurnNumber: int64(audit.turn_number),
+			SpeciesID:  int64(sp.id),
+			Tech:       tech_abbr[audit.tech],
+			OldLevel:   int64(audit.old_level),
+			NewLevel:   int64(audit.new_level),
+			Cost:       int64(audit.cost),
+			Reason:     audit.reason.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import "fmt"
+
+// telescope_range returns the range in parsecs of a gravitic telescope built
+// from the given number of GT units. Every two units add a parsec, up to one
+// parsec for every ten levels of gravitics.
+func (sp *species_data_t) telescope_range(gt_units int) int {
+	return min(gt_units/2, sp.tech_level[GV]/10)
+}
+
+// do_telescope_command executes a TELESCOPE order:
+//
+//	TELESCOPE PL name
+//	TELESCOPE ship
+//
+// The GT units at the planet or on the ship search for alien ships and
+// colonies within range. A ship is detected with a chance of twice its
+// tonnage, in units of 10,000 tons. A colony is detected with a chance of one
+// twentieth of its economic base. Neither chance can exceed 95 percent.
+// Landed ships and hidden colonies can't be detected, and disguised ships are
+// detected without their name or owner. Every detection is recorded as a
+// TELESCOPE_DETECTION transaction so that the target learns of it.
+func (g *galaxy_data_t) do_telescope_command(sp *species_data_t, o *order_t) {
+	holder, reason := sp.get_cargo_holder(o)
+	if reason != "" {
+		sp.report.order_ignored(o, reason)
+		return
+	}
+	gt_units := holder.item_quantity()[GT]
+	if gt_units < 2 {
+		sp.report.order_ignored(o, fmt.Sprintf("%s needs at least 2 GT units to operate a telescope.", holder))
+		return
+	}
+	telescope_range := sp.telescope_range(gt_units)
+	if telescope_range < 1 {
+		sp.report.order_ignored(o, "Your gravitics tech level is too low to operate a telescope.")
+		return
+	} else if g.ledger_full() {
+		sp.report.order_ignored(o, "Too many transactions this turn.")
+		return
+	}
+	var x, y, z int
+	if holder.nampla != nil {
+		x, y, z = holder.nampla.x, holder.nampla.y, holder.nampla.z
+	} else {
+		x, y, z = holder.ship.x, holder.ship.y, holder.ship.z
+	}
+
+	sp.report.printf("\nGravitic telescope at %s, with a range of %d parsecs:\n", holder, telescope_range)
+	detected, full := 0, false
+scan:
+	for _, system := range g.scan_near(x, y, z, telescope_range) {
+		star := system.star
+		for _, alien := range g.species {
+			if alien == sp {
+				continue
+			}
+			for _, ship := range alien.ships {
+				if ship.x != star.x || ship.y != star.y || ship.z != star.z {
+					continue
+				} else if ship.status == ON_SURFACE || ship.status == UNDER_CONSTRUCTION {
+					continue
+				} else if rnd(100) > min(2*ship.tonnage, 95) {
+					continue
+				}
+				if !g.telescope_detection(sp, alien, star, ship.pn, ship.ship_name(), ship_view_t{ship: ship, owner: alien}) {
+					full = true
+					break scan
+				}
+				detected++
+			}
+			for _, nampla := range alien.namplas {
+				if nampla.x != star.x || nampla.y != star.y || nampla.z != star.z {
+					continue
+				} else if nampla.status&POPULATED == 0 || nampla.hidden || nampla.hiding {
+					continue
+				} else if rnd(100) > min((nampla.mi_base+nampla.ma_base)/20, 95) {
+					continue
+				}
+				if !g.telescope_detection(sp, alien, star, nampla.pn, "PL "+nampla.name, plain_view_t("PL "+nampla.name+" of SP "+alien.name)) {
+					full = true
+					break scan
+				}
+				detected++
+			}
+		}
+	}
+	if full {
+		sp.report.printf("  Too many transactions this turn; the search was cut short.\n")
+	} else if detected == 0 {
+		sp.report.printf("  Nothing was detected.\n")
+	}
+}
+
+// telescope_detection records a ship or colony detected by a telescope and reports the detection.
+// The ledger keeps the target's own name for it and the description the
+// telescope's owner saw, so disguised ships stay disguised.
+// Returns false, without reporting anything, if the ledger is full.
+func (g *galaxy_data_t) telescope_detection(sp, alien *species_data_t, star *star_data_t, pn int, name string, target observable) bool {
+	if !g.add_transaction(&trans_data_t{
+		type_:     TELESCOPE_DETECTION,
+		donor:     sp.id,
+		recipient: alien.id,
+		x:         star.x,
+		y:         star.y,
+		z:         star.z,
+		pn:        pn,
+		name1:     name,
+		name2:     target.observed_by(sp),
+	}) {
+		return false
+	}
+	sp.report.printf("  %s at x = %d, y = %d, z = %d", target.observed_by(sp), star.x, star.y, star.z)
+	if pn != 0 {
+		sp.report.printf(", planet #%d", pn)
+	}
+	sp.report.printf(".\n")
+	return true
+}
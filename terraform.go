@@ -0,0 +1,175 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// TPS_PER_TERRAFORM is the number of TP units used up by each change a
+// terraforming operation makes to a planet.
+const TPS_PER_TERRAFORM = 3
+
+// do_terraform_command executes a TERRAFORM order:
+//
+//	TERRAFORM PL name
+//	TERRAFORM n PL name
+//
+// The TP units at the named planet are used to make it more like the species'
+// home planet. Every TPS_PER_TERRAFORM units make one change, and each change
+// reduces the life support needed on the planet by 3 points. Poisonous gases
+// are replaced first, then the required gas is added, and finally the
+// temperature and pressure classes are moved one class at a time toward those
+// of the home planet. If n is given, no more than n TP units are used.
+func (g *galaxy_data_t) do_terraform_command(sp *species_data_t, o *order_t) {
+	limit, ok := o.get_value()
+	if ok && limit < TPS_PER_TERRAFORM {
+		sp.report.order_ignored(o, fmt.Sprintf("At least %d TP units are needed to terraform a planet.", TPS_PER_TERRAFORM))
+		return
+	}
+	if o.get_class_abbr() != PLANET_ID {
+		sp.report.order_ignored(o, "Invalid or missing planet abbreviation.")
+		return
+	}
+	name := o.get_name()
+	nampla := sp.find_nampla(name)
+	if nampla == nil {
+		sp.report.order_ignored(o, fmt.Sprintf("You do not have a planet named %q.", name))
+		return
+	} else if nampla.planet == nil || sp.home.planet == nil {
+		sp.report.order_ignored(o, fmt.Sprintf("PL %s can't be terraformed.", nampla.name))
+		return
+	}
+	available := nampla.item_quantity[TP]
+	if ok && limit < available {
+		available = limit
+	}
+	if available < TPS_PER_TERRAFORM {
+		sp.report.order_ignored(o, fmt.Sprintf("PL %s needs at least %d TP units to be terraformed.", nampla.name, TPS_PER_TERRAFORM))
+		return
+	}
+	planet := nampla.planet
+	if life_support_needed(sp, sp.home.planet, planet) == 0 {
+		sp.report.order_ignored(o, fmt.Sprintf("PL %s does not need to be terraformed.", nampla.name))
+		return
+	}
+
+	used := 0
+	for used+TPS_PER_TERRAFORM <= available && life_support_needed(sp, sp.home.planet, planet) > 0 {
+		if !g.terraform(sp, planet) {
+			break
+		}
+		used += TPS_PER_TERRAFORM
+	}
+	nampla.item_quantity[TP] -= used
+	sp.report.printf("PL %s was terraformed using %s. Life support needed is now %d.\n",
+		nampla.name, item_quantity_name(TP, used), life_support_needed(sp, sp.home.planet, planet))
+}
+
+// terraform makes a single change to the planet to move it toward the species'
+// needs and records the change in the planet's history.
+// Returns false if nothing more can be changed.
+func (g *galaxy_data_t) terraform(sp *species_data_t, planet *planet_data_t) bool {
+	home := sp.home.planet
+
+	// replace a poisonous gas with the required gas, or remove it if the required gas is already present
+	for n, gas := range planet.gas {
+		if gas == GAS_NONE || planet.gas_percent[n] == 0 || !sp.is_poisoned_by(gas) {
+			continue
+		}
+		old_value := planet.atmosphere()
+		if sp.has_required_gas(planet) {
+			planet.gas[n], planet.gas_percent[n] = GAS_NONE, 0
+		} else {
+			percent := min(max(planet.gas_percent[n], sp.required_gas_min), sp.required_gas_max)
+			planet.gas[n], planet.gas_percent[n] = sp.required_gas, percent
+			planet.merge_gas(n)
+		}
+		g.record_planet_change(sp, planet, "ATMOSPHERE", old_value, planet.atmosphere(), "TERRAFORM")
+		return true
+	}
+
+	// add the required gas, or bring it into the range the species needs
+	if !sp.has_required_gas(planet) {
+		old_value := planet.atmosphere()
+		percent := (sp.required_gas_min + sp.required_gas_max) / 2
+		slot := -1
+		for n, gas := range planet.gas {
+			if gas == sp.required_gas && planet.gas_percent[n] > 0 {
+				slot = n
+				break
+			} else if slot == -1 && (gas == GAS_NONE || planet.gas_percent[n] == 0) {
+				slot = n
+			}
+		}
+		if slot == -1 {
+			// no room in the atmosphere, so the least abundant gas is replaced
+			slot = 0
+			for n := range planet.gas {
+				if planet.gas_percent[n] < planet.gas_percent[slot] {
+					slot = n
+				}
+			}
+		}
+		planet.gas[slot], planet.gas_percent[slot] = sp.required_gas, percent
+		g.record_planet_change(sp, planet, "ATMOSPHERE", old_value, planet.atmosphere(), "TERRAFORM")
+		return true
+	}
+
+	if planet.temperature_class != home.temperature_class {
+		old_value := planet.temperature_class
+		if planet.temperature_class < home.temperature_class {
+			planet.temperature_class++
+		} else {
+			planet.temperature_class--
+		}
+		g.record_planet_change(sp, planet, "TEMPERATURE_CLASS", strconv.Itoa(old_value), strconv.Itoa(planet.temperature_class), "TERRAFORM")
+		return true
+	}
+
+	if planet.pressure_class != home.pressure_class {
+		old_value := planet.pressure_class
+		if planet.pressure_class < home.pressure_class {
+			planet.pressure_class++
+		} else {
+			planet.pressure_class--
+		}
+		g.record_planet_change(sp, planet, "PRESSURE_CLASS", strconv.Itoa(old_value), strconv.Itoa(planet.pressure_class), "TERRAFORM")
+		return true
+	}
+
+	return false
+}
+
+// is_poisoned_by returns true if the gas is poisonous to the species.
+func (sp *species_data_t) is_poisoned_by(gas gas_e) bool {
+	for _, poison := range sp.poison_gas {
+		if poison != GAS_NONE && poison == gas {
+			return true
+		}
+	}
+	return false
+}
+
+// has_required_gas returns true if the planet's atmosphere holds the species'
+// required gas in the range it needs.
+func (sp *species_data_t) has_required_gas(planet *planet_data_t) bool {
+	for n, gas := range planet.gas {
+		if gas == sp.required_gas && sp.required_gas_min <= planet.gas_percent[n] && planet.gas_percent[n] <= sp.required_gas_max {
+			return true
+		}
+	}
+	return false
+}
+
+// merge_gas combines the gas in slot n with any other slot holding the same gas,
+// so that a gas is never listed twice in the atmosphere.
+func (p *planet_data_t) merge_gas(n int) {
+	for m, gas := range p.gas {
+		if m != n && gas == p.gas[n] && p.gas_percent[m] > 0 {
+			p.gas_percent[n] += p.gas_percent[m]
+			p.gas[m], p.gas_percent[m] = GAS_NONE, 0
+		}
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"context"
+	"fmt"
+	"github.com/playbymail/fhgo/sqlc/sqlite3"
+)
+
+var transaction_name = [...]string{
+	INTERSPECIES_TRANSACTION_TYPE_UNKNOWN: "UNKNOWN",
+	EU_TRANSFER:                           "EU_TRANSFER",
+	MESSAGE_TO_SPECIES:                    "MESSAGE_TO_SPECIES",
+	BESIEGE_PLANET:                        "BESIEGE_PLANET",
+	SIEGE_EU_TRANSFER:                     "SIEGE_EU_TRANSFER",
+	TECH_TRANSFER:                         "TECH_TRANSFER",
+	DETECTION_DURING_SIEGE:                "DETECTION_DURING_SIEGE",
+	SHIP_MISHAP:                           "SHIP_MISHAP",
+	ASSIMILATION:                          "ASSIMILATION",
+	INTERSPECIES_CONSTRUCTION:             "INTERSPECIES_CONSTRUCTION",
+	TELESCOPE_DETECTION:                   "TELESCOPE_DETECTION",
+	ALIEN_JUMP_PORTAL_USAGE:               "ALIEN_JUMP_PORTAL_USAGE",
+	KNOWLEDGE_TRANSFER:                    "KNOWLEDGE_TRANSFER",
+	LANDING_REQUEST:                       "LANDING_REQUEST",
+	LOOTING_EU_TRANSFER:                   "LOOTING_EU_TRANSFER",
+	ALLIES_ORDER:                          "ALLIES_ORDER",
+	ITEM_TRANSFER:                         "ITEM_TRANSFER",
+	SHIP_UPGRADE:                          "SHIP_UPGRADE",
+	SHIP_RECYCLE:                          "SHIP_RECYCLE",
+	ITEM_RECYCLE:                          "ITEM_RECYCLE",
+	COLONY_DISBANDED:                      "COLONY_DISBANDED",
+}
+
+func (t interspecies_transaction_e) String() string {
+	if 0 <= t && int(t) < len(transaction_name) {
+		return transaction_name[t]
+	}
+	return fmt.Sprintf("interspecies_transaction_e(%d)", int(t))
+}
+
+// transaction_from_name returns the transaction type with the given name.
+func transaction_from_name(name string) interspecies_transaction_e {
+	for t, s := range transaction_name {
+		if s == name {
+			return interspecies_transaction_e(t)
+		}
+	}
+	return INTERSPECIES_TRANSACTION_TYPE_UNKNOWN
+}
+
+// ledger_full returns true if no more transactions can be recorded this turn.
+// Orders that spend or move something check it first, so that they can be
+// refused before anything is taken.
+func (g *galaxy_data_t) ledger_full() bool {
+	return len(g.transactions) >= MAX_TRANSACTIONS
+}
+
+// add_transaction appends a transaction to the turn's ledger.
+// Returns false if the ledger is full; the transaction is not recorded in that case.
+func (g *galaxy_data_t) add_transaction(t *trans_data_t) bool {
+	if g.ledger_full() {
+		return false
+	}
+	g.transactions = append(g.transactions, t)
+	return true
+}
+
+// species_name returns the name of the species with the given id, for reports.
+func (g *galaxy_data_t) species_name(id species_id_t) string {
+	if sp := g.find_species(id); sp != nil {
+		return sp.name
+	}
+	return fmt.Sprintf("#%d", id)
+}
+
+// describe returns a line describing the transaction from the point of view of one of the species in it.
+func (g *galaxy_data_t) describe(t *trans_data_t, viewer species_id_t) string {
+	donor, recipient := g.species_name(t.donor), g.species_name(t.recipient)
+	sent := viewer == t.donor
+	switch t.type_ {
+	case EU_TRANSFER:
+		if sent {
+			return fmt.Sprintf("You sent %d economic units to SP %s.", t.value, recipient)
+		}
+		return fmt.Sprintf("SP %s sent you %d economic units.", donor, t.value)
+	case TECH_TRANSFER:
+		tech := tech_level_e(t.number1)
+		if sent {
+			return fmt.Sprintf("You taught SP %s %s up to level %d.", recipient, tech, t.value)
+		}
+		return fmt.Sprintf("SP %s taught you %s up to level %d.", donor, tech, t.value)
+	case ITEM_TRANSFER:
+		what := item_quantity_name(item_e(t.number1), t.value)
+		if sent {
+			return fmt.Sprintf("You transferred %s from %s to %s of SP %s.", what, t.name1, t.name2, recipient)
+		}
+		return fmt.Sprintf("SP %s transferred %s from %s to your %s.", donor, what, t.name1, t.name2)
+	case LANDING_REQUEST:
+		outcome := "denied"
+		if t.value != 0 {
+			outcome = "granted"
+		}
+		if sent {
+			return fmt.Sprintf("Your request to land %s on PL %s of SP %s was %s.", t.name1, t.name2, recipient, outcome)
+		}
+		return fmt.Sprintf("SP %s asked to land %s on PL %s. Permission was %s.", donor, t.name1, t.name2, outcome)
+	case MESSAGE_TO_SPECIES:
+		if sent {
+			return fmt.Sprintf("You sent a message to SP %s.", recipient)
+		}
+		return fmt.Sprintf("You received a message from SP %s.", donor)
+	case TELESCOPE_DETECTION:
+		if sent {
+			return fmt.Sprintf("Your gravitic telescope detected %s at x = %d, y = %d, z = %d.", t.name2, t.x, t.y, t.z)
+		}
+		return fmt.Sprintf("Your %s at x = %d, y = %d, z = %d was detected by a gravitic telescope of SP %s.", t.name1, t.x, t.y, t.z, donor)
+	case ALIEN_JUMP_PORTAL_USAGE:
+		if sent {
+			return fmt.Sprintf("%s used the jump portal %s of SP %s.", t.name1, t.name2, recipient)
+		}
+		return fmt.Sprintf("%s of SP %s used your jump portal %s.", t.name1, donor, t.name2)
+	case SHIP_UPGRADE:
+		return fmt.Sprintf("You upgraded %s by %d years for %d economic units.", t.name1, t.number1, t.value)
+	case SHIP_RECYCLE:
+		return fmt.Sprintf("You recycled %s, aged %d, for %d economic units.", t.name1, t.number1, t.value)
+	case ITEM_RECYCLE:
+		return fmt.Sprintf("You recycled %s on %s for %d economic units.", item_quantity_name(item_e(t.number1), t.number2), t.name1, t.value)
+	case COLONY_DISBANDED:
+		return fmt.Sprintf("You disbanded %s.", t.name1)
+	case BESIEGE_PLANET:
+		if sent {
+			return fmt.Sprintf("PL %s was besieged by SP %s with %d%% effectiveness.", t.name1, recipient, t.value)
+		}
+		return fmt.Sprintf("You besieged PL %s of SP %s with %d%% effectiveness.", t.name1, donor, t.value)
+	case SIEGE_EU_TRANSFER:
+		if sent {
+			return fmt.Sprintf("The siege of PL %s cost you %d economic units, taken by SP %s.", t.name1, t.value, recipient)
+		}
+		return fmt.Sprintf("The siege of PL %s of SP %s yielded %d economic units.", t.name1, donor, t.value)
+	case LOOTING_EU_TRANSFER:
+		if sent {
+			return fmt.Sprintf("SP %s looted %d economic units from the cargo of %s.", recipient, t.value, t.name1)
+		}
+		return fmt.Sprintf("You looted %d economic units from the cargo of %s of SP %s.", t.value, t.name1, donor)
+	}
+	if sent {
+		return fmt.Sprintf("%s with SP %s, value %d.", t.type_, recipient, t.value)
+	}
+	return fmt.Sprintf("%s from SP %s, value %d.", t.type_, donor, t.value)
+}
+
+// report_transactions adds the turn's transactions involving the species to its report.
+// This includes the species' own entries in the ledger, such as ship upgrades.
+func (g *galaxy_data_t) report_transactions(sp *species_data_t) {
+	header := false
+	for _, t := range g.transactions {
+		if t.donor != sp.id && t.recipient != sp.id {
+			continue
+		}
+		if !header {
+			sp.report.printf("\nTransactions:\n")
+			header = true
+		}
+		sp.report.printf("  %s\n", g.describe(t, sp.id))
+	}
+}
+
+// apply_transactions carries out the transactions that take effect at the end of the turn.
+func (g *galaxy_data_t) apply_transactions() {
+	for _, t := range g.transactions {
+		switch t.type_ {
+		case TECH_TRANSFER:
+			if recipient := g.find_species(t.recipient); recipient != nil {
+				recipient.learn_tech(tech_level_e(t.number1), t.value)
+			}
+		}
+	}
+}
+
+// save_transactions appends the transactions made since the ledger was loaded
+// to the turn's ledger. Transactions saved by earlier phases are left alone.
+func (g *galaxy_data_t) save_transactions(ctx context.Context, q *sqlite3.Queries) error {
+	last := 0
+	for _, t := range g.transactions {
+		last = max(last, t.seq)
+	}
+	for _, t := range g.transactions {
+		if t.seq != 0 {
+			continue
+		}
+		last++
+		err := q.CreateTransaction(ctx, sqlite3.CreateTransactionParams{
+			TurnNumber:  int64(g.turn_number),
+			Seq:         int64(last),
+			Type:        t.type_.String(),
+			DonorID:     int64(t.donor),
+			RecipientID: int64(t.recipient),
+			Value:       int64(t.value),
+			X:           int64(t.x),
+			Y:           int64(t.y),
+			Z:           int64(t.z),
+			Pn:          int64(t.pn),
+			Number1:     int64(t.number1),
+			Name1:       t.name1,
+			Number2:     int64(t.number2),
+			Name2:       t.name2,
+			Number3:     int64(t.number3),
+			Name3:       t.name3,
+		})
+		if err != nil {
+			return err
+		}
+		t.seq = last
+	}
+	return nil
+}
+
+// load_transactions reads the turn's transactions from the ledger, so that
+// phases run separately can add to the transactions of earlier phases.
+func (g *galaxy_data_t) load_transactions(ctx context.Context, q *sqlite3.Queries) error {
+	rows, err := q.ListTransactions(ctx, int64(g.turn_number))
+	if err != nil {
+		return err
+	}
+	g.transactions = nil
+	for _, row := range rows {
+		g.transactions = append(g.transactions, &trans_data_t{
+			type_:     transaction_from_name(row.Type),
+			donor:     species_id_t(row.DonorID),
+			recipient: species_id_t(row.RecipientID),
+			value:     int(row.Value),
+			x:         int(row.X),
+			y:         int(row.Y),
+			z:         int(row.Z),
+			pn:        int(row.Pn),
+			number1:   int(row.Number1),
+			name1:     row.Name1,
+			number2:   int(row.Number2),
+			name2:     row.Name2,
+			number3:   int(row.Number3),
+			name3:     row.Name3,
+			seq:       int(row.Seq),
+		})
+	}
+	return nil
+}
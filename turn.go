@@ -0,0 +1,83 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+import (
+	"context"
+	"github.com/playbymail/fhgo/sqlc/sqlite3"
+)
+
+// finish_turn runs the end-of-turn updates for every species.
+func (g *galaxy_data_t) finish_turn() {
+	g.apply_transactions()
+	g.update_contacts()
+	g.update_mining_difficulty()
+	g.grow_population()
+	g.portal_tonnage = nil
+	g.estimates = nil
+	for _, sp := range g.species {
+		g.report_transactions(sp)
+		g.report_messages(sp)
+		g.report_planet_changes(sp)
+		g.report_population(sp)
+		sp.update_tech_levels(g.turn_number)
+		sp.recover_home_planet()
+		sp.report_defenses()
+		sp.pay_fleet_maintenance()
+		sp.report_fleet()
+
+		// colonies hidden this turn stay hidden through the next turn's battles;
+		// ambushes and surprise were used up by the strikes, and sieges and
+		// recruiting last only for the turn
+		for _, nampla := range sp.namplas {
+			nampla.hidden, nampla.hiding = nampla.hiding, false
+			nampla.use_on_ambush = 0
+			nampla.siege_eff = 0
+			nampla.recruited = 0
+		}
+		for _, ship := range sp.ships {
+			ship.just_jumped = false
+			if ship.status == JUMPED_IN_COMBAT {
+				ship.status = IN_DEEP_SPACE
+			}
+		}
+	}
+	g.generate_auto_orders()
+}
+
+// save_turn persists the changes made to the galaxy and every species during
+// the turn. Everything is saved in one transaction, so an error leaves the
+// database as it was before the phase.
+func (g *galaxy_data_t) save_turn(ctx context.Context, q *sqlite3.Queries) error {
+	return q.InTx(ctx, func(q *sqlite3.Queries) error {
+		if err := g.save_galaxy(ctx, q); err != nil {
+			return err
+		} else if err := g.save_phase(ctx, q); err != nil {
+			return err
+		}
+		if err := g.save_species(ctx, q); err != nil {
+			return err
+		}
+		for _, sp := range g.species {
+			if err := sp.save_tech_levels(ctx, q, g.turn_number); err != nil {
+				return err
+			}
+		}
+		if err := g.save_relations(ctx, q); err != nil {
+			return err
+		}
+		if err := g.save_messages(ctx, q); err != nil {
+			return err
+		}
+		if err := g.save_planets(ctx, q); err != nil {
+			return err
+		}
+		if err := g.save_population(ctx, q); err != nil {
+			return err
+		}
+		if err := g.save_auto_orders(ctx, q); err != nil {
+			return err
+		}
+		return g.save_transactions(ctx, q)
+	})
+}
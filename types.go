@@ -4,7 +4,7 @@ package fhgo
 
 type action_data_t struct {
 	num_units_fighting     int
-	fighting_species_index [MAX_SHIPS]species_id_t
+	fighting_species_index [MAX_SHIPS]int // index of the unit's species in the battle
 	num_shots              [MAX_SHIPS]int
 	shots_left             [MAX_SHIPS]int
 	weapon_damage          [MAX_SHIPS]int64
@@ -12,31 +12,34 @@ type action_data_t struct {
 	shield_strength_left   [MAX_SHIPS]int64
 	original_age_or_PDs    [MAX_SHIPS]int64
 	bomb_damage            [MAX_SHIPS]int64
-	surprised              [MAX_SHIPS]byte
-	unit_type              [MAX_SHIPS]byte
-	fighting_unit          [MAX_SHIPS]string
+	surprised              [MAX_SHIPS]bool
+	unit_type              [MAX_SHIPS]combatant_type_e
+	fighting_unit          [MAX_SHIPS]any // *ship_data_t or *nampla_data_t, depending on unit_type
 }
 type action_data = action_data_t
 
 type battle_data_t struct {
 	x, y, z, pn               int
-	num_species_here          byte
+	num_species_here          int
 	spec_num                  [MAX_SPECIES]species_id_t
-	summary_only              [MAX_SPECIES]species_id_t
-	transport_withdraw_age    [MAX_SPECIES]species_id_t
-	warship_withdraw_age      [MAX_SPECIES]species_id_t
-	fleet_withdraw_percentage [MAX_SPECIES]species_id_t
-	haven_x                   [MAX_SPECIES]species_id_t
-	haven_y                   [MAX_SPECIES]species_id_t
-	haven_z                   [MAX_SPECIES]species_id_t
-	special_target            [MAX_SPECIES]species_id_t
-	hijacker                  [MAX_SPECIES]species_id_t
-	can_be_surprised          [MAX_SPECIES]species_id_t
-	enemy_mine                [MAX_SPECIES][MAX_SPECIES]species_id_t
-	num_engage_options        [MAX_SPECIES]species_id_t
-	engage_option             [MAX_SPECIES][MAX_ENGAGE_OPTIONS]species_id_t
-	engage_planet             [MAX_SPECIES][MAX_ENGAGE_OPTIONS]species_id_t
+	species                   [MAX_SPECIES]*species_data_t
+	summary_only              [MAX_SPECIES]bool
+	transport_withdraw_age    [MAX_SPECIES]int
+	warship_withdraw_age      [MAX_SPECIES]int
+	fleet_withdraw_percentage [MAX_SPECIES]int
+	haven_x                   [MAX_SPECIES]int
+	haven_y                   [MAX_SPECIES]int
+	haven_z                   [MAX_SPECIES]int
+	special_target            [MAX_SPECIES]special_target_e
+	hijacker                  [MAX_SPECIES]bool
+	can_be_surprised          [MAX_SPECIES]bool
+	enemy_mine                [MAX_SPECIES][MAX_SPECIES]bool
+	num_engage_options        [MAX_SPECIES]int
+	engage_option             [MAX_SPECIES][MAX_ENGAGE_OPTIONS]combat_action_e
+	engage_planet             [MAX_SPECIES][MAX_ENGAGE_OPTIONS]int
 	ambush_amount             [MAX_SPECIES]int
+	first_strike              [MAX_SPECIES]bool // set if the species' ambush took an enemy by surprise
+	captures                  []capture_t       // ships taken by hijackers during the battle
 }
 type battle_data = battle_data_t
 
@@ -69,6 +72,7 @@ type nampla_data_t struct {
 	mi_base        int             // Mining base times 10
 	ma_base        int             // Manufacturing base times 10
 	pop_units      int             // Number of available population units
+	recruited      int             // Population units recruited as CUs this turn
 	item_quantity  [MAX_ITEMS]int  // Quantity of each item available
 	use_on_ambush  int             // Amount to use on ambush
 	message        message_id_t    // Message associated with this planet, if any
@@ -89,7 +93,7 @@ type ship_data_t struct {
 	name                   string         // Name of ship
 	x, y, z, pn            int            // Current coordinates
 	status                 ship_status_e  // Current status of ship
-	type_                  ship_type_e    // Ship type
+	type_                  ship_e         // Ship type
 	dest_x, dest_y, dest_z int            // Destination if ship was forced to jump from combat. Also used by TELESCOPE command
 	just_jumped            bool           // Set if ship jumped this turn
 	arrived_via_wormhole   bool           // Ship arrived via wormhole in the PREVIOUS turn
@@ -97,6 +101,7 @@ type ship_data_t struct {
 	tonnage                int            // Ship tonnage divided by 10,000
 	item_quantity          [MAX_ITEMS]int // Quantity of each item carried
 	age                    int            // Ship age
+	damage                 int            // Years of the ship's age caused by battle damage that hasn't been repaired
 	remaining_cost         int            // The cost needed to complete the ship if still under construction
 	loading_point          nampla_id_t    // Nampla index for planet where ship was last loaded with CUs. Zero = none. Use 9999 for home planet
 	unloading_point        nampla_id_t    // Nampla index for planet that ship should be given orders to jump to where it will unload. Zero = none. Use 9999 for home planet
@@ -168,6 +173,10 @@ type species_data_t struct {
 		planet *planet_data_t // pointer to the planet containing the colony
 		nampla *nampla_data_t // pointer to the nampla defining the colony
 	}
+	namplas    []*nampla_data_t // named planets, including home planet and colonies
+	ships      []*ship_data_t   // ships owned by the species
+	tech_audit []*tech_audit_t  // changes to tech levels made during the current turn
+	report     report_t         // status report for the current turn
 }
 
 type species_id_t int
@@ -206,6 +215,7 @@ type trans_data_t struct {
 	name2       string
 	number3     int
 	name3       string
+	seq         int // position in the turn's ledger, zero until the transaction is saved
 }
 
 type wormhole_data_t struct {
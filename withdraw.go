@@ -0,0 +1,149 @@
+// Copyright (c) 2024 Michael D Henderson. All rights reserved.
+
+package fhgo
+
+// can_fight returns true if the ship can take part in combat.
+// Ships under construction, destroyed ships and ships that withdrew from combat can't.
+func (s *ship_data_t) can_fight() bool {
+	return s.status != UNDER_CONSTRUCTION && s.status != JUMPED_IN_COMBAT && s.age <= 49
+}
+
+// do_target_command executes a TARGET order:
+//
+//	TARGET n
+//
+// where n is 1 for warships, 2 for transports, 3 for starbases and 4 for
+// planetary defenses. Zero restores normal targeting.
+func (bat *battle_data_t) do_target_command(i int, o *order_t) {
+	value, ok := o.get_value()
+	if !ok || value < int(TARGET_NORMAL) || value > int(TARGET_PDS) {
+		bat.species[i].report.order_ignored(o, "Invalid or missing target type.")
+		return
+	}
+	bat.special_target[i] = special_target_e(value)
+}
+
+// do_withdraw_command executes a WITHDRAW order:
+//
+//	WITHDRAW transport_age warship_age fleet_percentage
+//
+// Transports and warships withdraw once their age exceeds the given ages.
+// The whole fleet withdraws once the percentage of its tonnage that has been
+// destroyed exceeds the given percentage.
+func (bat *battle_data_t) do_withdraw_command(i int, o *order_t) {
+	var values [3]int
+	for n := range values {
+		value, ok := o.get_value()
+		if !ok || value < 0 || value > 100 {
+			bat.species[i].report.order_ignored(o, "Invalid or missing withdrawal value.")
+			return
+		}
+		values[n] = value
+	}
+	bat.transport_withdraw_age[i] = values[0]
+	bat.warship_withdraw_age[i] = values[1]
+	bat.fleet_withdraw_percentage[i] = values[2]
+}
+
+// do_haven_command executes a HAVEN order:
+//
+//	HAVEN x y z
+//
+// Ships that withdraw from the battle jump to the haven.
+func (bat *battle_data_t) do_haven_command(i int, o *order_t) {
+	var coords [3]int
+	for n := range coords {
+		value, ok := o.get_value()
+		if !ok || value < 0 {
+			bat.species[i].report.order_ignored(o, "Invalid or missing coordinates.")
+			return
+		}
+		coords[n] = value
+	}
+	bat.haven_x[i], bat.haven_y[i], bat.haven_z[i] = coords[0], coords[1], coords[2]
+}
+
+// target_type returns the special target type of a unit.
+func (act *action_data_t) target_type(unit int) special_target_e {
+	switch u := act.fighting_unit[unit].(type) {
+	case *ship_data_t:
+		switch {
+		case u.class == TR:
+			return TARGET_TRANSPORTS
+		case u.class == BA:
+			return TARGET_STARBASES
+		}
+		return TARGET_WARSHIPS
+	case *nampla_data_t:
+		return TARGET_PDS
+	}
+	return TARGET_NORMAL
+}
+
+// choose_target picks the unit an attacker fires at. If the attacker's species
+// gave a TARGET order and targets of that type are available, three shots
+// out of four go to them. Otherwise, the target is picked at random.
+func (bat *battle_data_t) choose_target(act *action_data_t, attacker int, targets []int) int {
+	special_target := bat.special_target[act.fighting_species_index[attacker]]
+	if special_target != TARGET_NORMAL {
+		var preferred []int
+		for _, target := range targets {
+			if act.target_type(target) == special_target {
+				preferred = append(preferred, target)
+			}
+		}
+		if len(preferred) > 0 && rnd(100) <= 75 {
+			return preferred[rnd(len(preferred))-1]
+		}
+	}
+	return targets[rnd(len(targets))-1]
+}
+
+// check_withdrawals withdraws the ships that have reached their species'
+// withdrawal thresholds at the end of a round. Starbases and sub-light ships
+// can't jump, so they never withdraw. Withdrawn ships jump to their species'
+// haven, if one was given, and take no further part in combat this turn.
+func (bat *battle_data_t) check_withdrawals(act *action_data_t) {
+	var fleet_tonnage, lost_tonnage [MAX_SPECIES]int
+	for unit := 0; unit < act.num_units_fighting; unit++ {
+		if ship, ok := act.fighting_unit[unit].(*ship_data_t); ok {
+			i := act.fighting_species_index[unit]
+			fleet_tonnage[i] += ship.tonnage
+			if act.is_destroyed(unit) {
+				lost_tonnage[i] += ship.tonnage
+			}
+		}
+	}
+
+	for unit := 0; unit < act.num_units_fighting; unit++ {
+		ship, ok := act.fighting_unit[unit].(*ship_data_t)
+		if !ok || act.is_out(unit) || ship.type_ != FTL || ship.class == BA {
+			continue
+		}
+		i := act.fighting_species_index[unit]
+		fleet_withdraws := fleet_tonnage[i] > 0 && (100*lost_tonnage[i])/fleet_tonnage[i] > bat.fleet_withdraw_percentage[i]
+		switch {
+		case fleet_withdraws:
+		case ship.class == TR && ship.age > bat.transport_withdraw_age[i]:
+		case ship.class != TR && ship.age > bat.warship_withdraw_age[i]:
+		default:
+			continue
+		}
+		bat.withdraw(act, unit)
+	}
+}
+
+// withdraw takes a ship out of the battle and jumps it to its species' haven.
+func (bat *battle_data_t) withdraw(act *action_data_t, unit int) {
+	i := act.fighting_species_index[unit]
+	ship := act.fighting_unit[unit].(*ship_data_t)
+	ship.status = JUMPED_IN_COMBAT
+	if bat.haven_x[i] < 0 {
+		bat.log_printf(false, "    %s withdrew from combat.\n", act.unit_name(bat, unit))
+		return
+	}
+	ship.x, ship.y, ship.z, ship.pn = bat.haven_x[i], bat.haven_y[i], bat.haven_z[i], 0
+	ship.dest_x, ship.dest_y, ship.dest_z = ship.x, ship.y, ship.z
+	bat.log_printf(false, "    %s withdrew from combat and jumped to x = %d, y = %d, z = %d.\n",
+		act.unit_name(bat, unit), ship.x, ship.y, ship.z)
+}